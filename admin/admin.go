@@ -0,0 +1,294 @@
+// Package admin exposes scheduler control operations as an embeddable JSON REST API.
+//
+// Routes, relative to where the handler is mounted:
+//
+//	GET    /tasks              list tasks
+//	GET    /tasks/{id}         show one task
+//	PATCH  /tasks/{id}         update the cron expression: {"expression": "...", "seconds": true}
+//	DELETE /tasks/{id}         remove a task
+//	POST   /tasks/{id}/pause   pause a task
+//	POST   /tasks/{id}/resume  resume a paused task
+//	POST   /tasks/{id}/trigger run a task now
+//
+// Mount it under a prefix with http.StripPrefix:
+//
+//	mux.Handle("/admin/", http.StripPrefix("/admin", admin.NewHandler(scheduler)))
+//
+// Without WithAuthenticator the API is unauthenticated. With it, GET routes
+// require RoleReadOnly and all others RoleOperator; mutating operations are
+// audited with the principal's name as actor (see cron.Scheduler.WithAuditSink).
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+// Task is the JSON representation of a cron.TaskInfo.
+type Task struct {
+	ID         string     `json:"id"`
+	Expression string     `json:"expression"`
+	Resolved   string     `json:"resolved,omitempty"` // set when H tokens or a macro were resolved
+	Location   string     `json:"location"`
+	Timeout    string     `json:"timeout,omitempty"`
+	Retry      int        `json:"retry"`
+	Seconds    bool       `json:"seconds"`
+	Years      bool       `json:"years"`
+	Status     string     `json:"status"`
+	PrevRun    *time.Time `json:"prev_run,omitempty"`
+	NextRun    *time.Time `json:"next_run,omitempty"`
+	Stats      Stats      `json:"stats"`
+}
+
+// Stats is the JSON representation of cron.TaskStats.
+type Stats struct {
+	Runs                uint64 `json:"runs"`
+	Failures            uint64 `json:"failures"`
+	ConsecutiveFailures uint64 `json:"consecutive_failures"`
+	LastError           string `json:"last_error,omitempty"`
+	MissedFires         uint64 `json:"missed_fires"`
+}
+
+// UpdateRequest is the body of PATCH /tasks/{id}. Seconds and Years enable or
+// disable those fields; when omitted, the task's current setting is kept.
+type UpdateRequest struct {
+	Expression string `json:"expression"`
+	Seconds    *bool  `json:"seconds,omitempty"`
+	Years      *bool  `json:"years,omitempty"`
+}
+
+// options returns the parser options requested by r.
+func (r UpdateRequest) options() []cron.Option {
+	var opts []cron.Option
+	if r.Seconds != nil {
+		if *r.Seconds {
+			opts = append(opts, cron.WithSeconds())
+		} else {
+			opts = append(opts, cron.WithoutSeconds())
+		}
+	}
+	if r.Years != nil {
+		if *r.Years {
+			opts = append(opts, cron.WithYears())
+		} else {
+			opts = append(opts, cron.WithoutYears())
+		}
+	}
+	return opts
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// AnonymousActor is the audit actor for requests to a handler without authenticator.
+const AnonymousActor = "anonymous"
+
+// Option configures the admin handler.
+type Option func(*handler)
+
+// WithAuthenticator requires requests to authenticate with a and authorizes them by role.
+func WithAuthenticator(a Authenticator) Option {
+	return func(h *handler) {
+		h.auth = a
+	}
+}
+
+// NewHandler returns an http.Handler serving the admin API for s.
+func NewHandler(s *cron.Scheduler, opts ...Option) http.Handler {
+	h := &handler{scheduler: s}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tasks", h.authorize(RoleReadOnly, h.list))
+	mux.HandleFunc("GET /tasks/{id}", h.authorize(RoleReadOnly, h.get))
+	mux.HandleFunc("PATCH /tasks/{id}", h.authorize(RoleOperator, h.update))
+	mux.HandleFunc("DELETE /tasks/{id}", h.authorize(RoleOperator, h.remove))
+	mux.HandleFunc("POST /tasks/{id}/pause", h.authorize(RoleOperator, h.pause))
+	mux.HandleFunc("POST /tasks/{id}/resume", h.authorize(RoleOperator, h.resume))
+	mux.HandleFunc("POST /tasks/{id}/trigger", h.authorize(RoleOperator, h.trigger))
+	return mux
+}
+
+type handler struct {
+	scheduler *cron.Scheduler
+	auth      Authenticator
+}
+
+type principalKey struct{}
+
+// authorize authenticates the request and checks that the caller has at least role.
+func (h *handler) authorize(role Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.auth == nil {
+			next(w, r)
+			return
+		}
+
+		p, err := h.auth.Authenticate(r)
+		switch {
+		case errors.Is(err, ErrNoCredentials), errors.Is(err, ErrInvalidCredentials):
+			w.Header().Set("WWW-Authenticate", `Bearer realm="golitecron"`)
+			writeError(w, http.StatusUnauthorized, err.Error())
+			return
+		case err != nil:
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		case p.Role < role:
+			writeError(w, http.StatusForbidden, "role "+p.Role.String()+" may not perform this operation")
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, p)))
+	}
+}
+
+// controller returns a scheduler controller acting as the authenticated caller.
+func (h *handler) controller(r *http.Request) *cron.Controller {
+	actor := AnonymousActor
+	if p, ok := r.Context().Value(principalKey{}).(Principal); ok {
+		actor = p.Name
+	}
+	return h.scheduler.AsActor(actor)
+}
+
+func (h *handler) list(w http.ResponseWriter, r *http.Request) {
+	infos := h.scheduler.Tasks()
+	tasks := make([]Task, 0, len(infos))
+	for _, info := range infos {
+		tasks = append(tasks, NewTask(info))
+	}
+	writeJSON(w, http.StatusOK, tasks)
+}
+
+func (h *handler) get(w http.ResponseWriter, r *http.Request) {
+	h.respondTask(w, r.PathValue("id"), http.StatusOK)
+}
+
+func (h *handler) update(w http.ResponseWriter, r *http.Request) {
+	var req UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if strings.TrimSpace(req.Expression) == "" {
+		writeError(w, http.StatusBadRequest, "expression is required")
+		return
+	}
+
+	id := r.PathValue("id")
+	if err := h.controller(r).UpdateTask(id, req.Expression, req.options()...); err != nil {
+		writeControlError(w, err)
+		return
+	}
+	h.respondTask(w, id, http.StatusOK)
+}
+
+func (h *handler) remove(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !h.controller(r).RemoveTaskByID(id) {
+		writeControlError(w, cron.ErrTaskNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *handler) pause(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := h.controller(r).PauseTask(id); err != nil {
+		writeControlError(w, err)
+		return
+	}
+	h.respondTask(w, id, http.StatusOK)
+}
+
+func (h *handler) resume(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := h.controller(r).ResumeTask(id); err != nil {
+		writeControlError(w, err)
+		return
+	}
+	h.respondTask(w, id, http.StatusOK)
+}
+
+func (h *handler) trigger(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := h.controller(r).TriggerTask(id); err != nil {
+		writeControlError(w, err)
+		return
+	}
+	h.respondTask(w, id, http.StatusAccepted)
+}
+
+func (h *handler) respondTask(w http.ResponseWriter, id string, status int) {
+	info, ok := h.scheduler.Task(id)
+	if !ok {
+		writeControlError(w, cron.ErrTaskNotFound)
+		return
+	}
+	writeJSON(w, status, NewTask(info))
+}
+
+// NewTask converts a TaskInfo into its JSON representation.
+func NewTask(info cron.TaskInfo) Task {
+	t := Task{
+		ID:         info.ID,
+		Expression: info.Expression,
+		Location:   info.Location.String(),
+		Retry:      info.Retry,
+		Seconds:    info.EnableSeconds,
+		Years:      info.EnableYears,
+		Status:     info.Status.String(),
+		Stats: Stats{
+			Runs:                info.Stats.Runs,
+			Failures:            info.Stats.Failures,
+			ConsecutiveFailures: info.Stats.ConsecutiveFailures,
+			LastError:           info.Stats.LastError,
+			MissedFires:         info.Stats.MissedFires,
+		},
+	}
+	if info.ResolvedExpression != info.Expression {
+		t.Resolved = info.ResolvedExpression
+	}
+	if info.Timeout > 0 {
+		t.Timeout = info.Timeout.String()
+	}
+	if !info.PrevRunTime.IsZero() {
+		t.PrevRun = &info.PrevRunTime
+	}
+	if !info.NextRunTime.IsZero() {
+		t.NextRun = &info.NextRunTime
+	}
+	return t
+}
+
+// writeControlError maps scheduler errors to status codes.
+func writeControlError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, cron.ErrTaskNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, cron.ErrInvalidExpression):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, cron.ErrNotRunning), errors.Is(err, cron.ErrTaskRunning):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,172 @@
+package admin
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+func newTestServer(t *testing.T) (*cron.Scheduler, *httptest.Server) {
+	t.Helper()
+	s := cron.NewScheduler()
+	job, _ := cron.WrapJob("report", func() error { return nil })
+	if err := s.AddTask("0 9 * * *", job, cron.WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/", http.StripPrefix("/admin", NewHandler(s)))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+func do(t *testing.T, method, url, body string) (*http.Response, []byte) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, data
+}
+
+func TestAdmin_ListAndGet(t *testing.T) {
+	_, srv := newTestServer(t)
+
+	resp, body := do(t, http.MethodGet, srv.URL+"/admin/tasks", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("list: status %d", resp.StatusCode)
+	}
+	var tasks []Task
+	if err := json.Unmarshal(body, &tasks); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "report" || tasks[0].Expression != "0 9 * * *" ||
+		tasks[0].Status != "scheduled" || tasks[0].NextRun == nil || tasks[0].Location != "UTC" {
+		t.Errorf("unexpected tasks %+v", tasks)
+	}
+
+	resp, _ = do(t, http.MethodGet, srv.URL+"/admin/tasks/report", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("get: status %d", resp.StatusCode)
+	}
+	resp, body = do(t, http.MethodGet, srv.URL+"/admin/tasks/missing", "")
+	if resp.StatusCode != http.StatusNotFound || !strings.Contains(string(body), "task not found") {
+		t.Errorf("get unknown: status %d body %s", resp.StatusCode, body)
+	}
+}
+
+func TestAdmin_PauseResume(t *testing.T) {
+	_, srv := newTestServer(t)
+
+	resp, body := do(t, http.MethodPost, srv.URL+"/admin/tasks/report/pause", "")
+	var task Task
+	if err := json.Unmarshal(body, &task); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("pause: status %d, %v", resp.StatusCode, err)
+	}
+	if task.Status != "paused" || task.NextRun != nil {
+		t.Errorf("expected paused task, got %+v", task)
+	}
+
+	resp, body = do(t, http.MethodPost, srv.URL+"/admin/tasks/report/resume", "")
+	task = Task{}
+	if err := json.Unmarshal(body, &task); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("resume: status %d, %v", resp.StatusCode, err)
+	}
+	if task.Status != "scheduled" || task.NextRun == nil {
+		t.Errorf("expected scheduled task, got %+v", task)
+	}
+
+	if resp, _ := do(t, http.MethodPost, srv.URL+"/admin/tasks/missing/pause", ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("pause unknown: status %d", resp.StatusCode)
+	}
+}
+
+func TestAdmin_Update(t *testing.T) {
+	_, srv := newTestServer(t)
+
+	resp, body := do(t, http.MethodPatch, srv.URL+"/admin/tasks/report", `{"expression":"30 18 * * 1-5"}`)
+	var task Task
+	if err := json.Unmarshal(body, &task); err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("update: status %d, %v", resp.StatusCode, err)
+	}
+	if task.Expression != "30 18 * * 1-5" || task.NextRun.Hour() != 18 {
+		t.Errorf("unexpected task after update %+v", task)
+	}
+
+	// Seconds are switched on and off again; omitted fields are kept.
+	for _, step := range []struct {
+		body    string
+		seconds bool
+	}{
+		{`{"expression":"0 30 18 * * 1-5","seconds":true}`, true},
+		{`{"expression":"15 30 18 * * 1-5"}`, true},
+		{`{"expression":"30 18 * * 1-5","seconds":false}`, false},
+	} {
+		resp, body := do(t, http.MethodPatch, srv.URL+"/admin/tasks/report", step.body)
+		task = Task{}
+		if err := json.Unmarshal(body, &task); err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("update %s: status %d, %v (%s)", step.body, resp.StatusCode, err, body)
+		}
+		if task.Seconds != step.seconds {
+			t.Errorf("update %s: seconds %v, want %v", step.body, task.Seconds, step.seconds)
+		}
+	}
+
+	cases := []struct {
+		url, body string
+		status    int
+	}{
+		{"/admin/tasks/report", `{"expression":"0 30 18 * * *"}`, http.StatusBadRequest},
+		{"/admin/tasks/report", `{"expression":"30 18 * * *","years":true}`, http.StatusBadRequest},
+		{"/admin/tasks/report", `{"expression":"61 * * * *"}`, http.StatusBadRequest},
+		{"/admin/tasks/report", `{}`, http.StatusBadRequest},
+		{"/admin/tasks/report", `not json`, http.StatusBadRequest},
+		{"/admin/tasks/missing", `{"expression":"* * * * *"}`, http.StatusNotFound},
+	}
+	for _, c := range cases {
+		if resp, body := do(t, http.MethodPatch, srv.URL+c.url, c.body); resp.StatusCode != c.status {
+			t.Errorf("PATCH %s %s: status %d, want %d (%s)", c.url, c.body, resp.StatusCode, c.status, body)
+		}
+	}
+}
+
+func TestAdmin_TriggerAndRemove(t *testing.T) {
+	s, srv := newTestServer(t)
+
+	if resp, _ := do(t, http.MethodPost, srv.URL+"/admin/tasks/report/trigger", ""); resp.StatusCode != http.StatusConflict {
+		t.Errorf("trigger on stopped scheduler: status %d", resp.StatusCode)
+	}
+
+	s.Start()
+	defer s.Stop()
+	if resp, _ := do(t, http.MethodPost, srv.URL+"/admin/tasks/report/trigger", ""); resp.StatusCode != http.StatusAccepted {
+		t.Errorf("trigger: status %d", resp.StatusCode)
+	}
+
+	if resp, _ := do(t, http.MethodDelete, srv.URL+"/admin/tasks/report", ""); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("delete: status %d", resp.StatusCode)
+	}
+	if resp, _ := do(t, http.MethodDelete, srv.URL+"/admin/tasks/report", ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("delete twice: status %d", resp.StatusCode)
+	}
+	if len(s.Tasks()) != 0 {
+		t.Errorf("expected no tasks, got %+v", s.Tasks())
+	}
+}
@@ -0,0 +1,243 @@
+package admin
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Role determines which routes a principal may call.
+type Role int
+
+const (
+	// RoleReadOnly may list and show tasks.
+	RoleReadOnly Role = iota
+	// RoleOperator may additionally update, remove, pause, resume and trigger tasks.
+	RoleOperator
+)
+
+func (r Role) String() string {
+	switch r {
+	case RoleReadOnly:
+		return "read-only"
+	case RoleOperator:
+		return "operator"
+	default:
+		return fmt.Sprintf("Role(%d)", int(r))
+	}
+}
+
+// Principal is an authenticated caller. Its Name is recorded as the audit actor.
+type Principal struct {
+	Name string
+	Role Role
+}
+
+var (
+	// ErrNoCredentials is returned by an Authenticator when the request carries none of its credentials.
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials is returned for credentials that are present but not accepted.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Authenticator identifies the caller of a request.
+type Authenticator interface {
+	Authenticate(r *http.Request) (Principal, error)
+}
+
+// Authenticators tries each authenticator in turn, skipping those that find no credentials.
+type Authenticators []Authenticator
+
+func (as Authenticators) Authenticate(r *http.Request) (Principal, error) {
+	for _, a := range as {
+		p, err := a.Authenticate(r)
+		if !errors.Is(err, ErrNoCredentials) {
+			return p, err
+		}
+	}
+	return Principal{}, ErrNoCredentials
+}
+
+// BearerTokens authenticates "Authorization: Bearer <token>" headers against static tokens.
+type BearerTokens map[string]Principal
+
+func (tokens BearerTokens) Authenticate(r *http.Request) (Principal, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return Principal{}, ErrNoCredentials
+	}
+	// Compare against every token so that timing does not reveal a match.
+	var found Principal
+	matched := 0
+	for t, p := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			found, matched = p, 1
+		}
+	}
+	if matched == 0 {
+		return Principal{}, ErrInvalidCredentials
+	}
+	return found, nil
+}
+
+// Headers used by HMAC-signed requests.
+const (
+	HeaderKeyID     = "X-Golitecron-Key"
+	HeaderTimestamp = "X-Golitecron-Timestamp"
+	HeaderNonce     = "X-Golitecron-Nonce"
+	HeaderSignature = "X-Golitecron-Signature"
+)
+
+// DefaultMaxClockSkew bounds how old a signed request may be.
+const DefaultMaxClockSkew = 5 * time.Minute
+
+// maxBodySize bounds the request bodies read for signature checks.
+const maxBodySize = 1 << 20
+
+// HMACKey is a shared secret and the principal it authenticates.
+type HMACKey struct {
+	Secret    []byte
+	Principal Principal
+}
+
+// HMACAuthenticator authenticates requests signed with SignRequest. A signature
+// covers the method, request URI, timestamp, nonce and body, and is accepted within
+// MaxClockSkew of the server clock (DefaultMaxClockSkew if zero). A nonce is
+// accepted once per key while its timestamp is within that window, so a captured
+// request cannot be replayed. Nonces are remembered in memory: handlers sharing
+// keys must share the HMACAuthenticator, and a restart forgets them.
+type HMACAuthenticator struct {
+	Keys         map[string]HMACKey // by key ID
+	MaxClockSkew time.Duration
+
+	mu   sync.Mutex
+	used map[usedNonce]time.Time // until when each nonce is remembered
+}
+
+type usedNonce struct {
+	keyID, nonce string
+}
+
+func (a *HMACAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	keyID := r.Header.Get(HeaderKeyID)
+	if keyID == "" {
+		return Principal{}, ErrNoCredentials
+	}
+	key, ok := a.Keys[keyID]
+	if !ok {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	ts, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+	skew := a.MaxClockSkew
+	if skew <= 0 {
+		skew = DefaultMaxClockSkew
+	}
+	if d := time.Since(time.Unix(ts, 0)); d > skew || d < -skew {
+		return Principal{}, ErrInvalidCredentials
+	}
+	nonce := r.Header.Get(HeaderNonce)
+	if nonce == "" {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return Principal{}, err
+	}
+	// RequestURI is the URI as received, before any http.StripPrefix.
+	uri := r.RequestURI
+	if uri == "" {
+		uri = r.URL.RequestURI()
+	}
+	want := signature(key.Secret, r.Method, uri, ts, nonce, body)
+	got, err := hex.DecodeString(r.Header.Get(HeaderSignature))
+	if err != nil || !hmac.Equal(got, want) {
+		return Principal{}, ErrInvalidCredentials
+	}
+	// Only nonces of valid signatures are recorded, so others cannot use them up.
+	if !a.useNonce(usedNonce{keyID, nonce}, time.Unix(ts, 0).Add(skew)) {
+		return Principal{}, ErrInvalidCredentials
+	}
+	return key.Principal, nil
+}
+
+// useNonce records n until expiry, when its timestamp leaves the accepted window,
+// and reports whether it was unused. Expired nonces are forgotten.
+func (a *HMACAuthenticator) useNonce(n usedNonce, expiry time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	if a.used == nil {
+		a.used = make(map[usedNonce]time.Time)
+	}
+	for k, until := range a.used {
+		if now.After(until) {
+			delete(a.used, k)
+		}
+	}
+	if _, seen := a.used[n]; seen {
+		return false
+	}
+	a.used[n] = expiry
+	return true
+}
+
+// SignRequest signs r with the given key for HMACAuthenticator. The request URI must
+// be the one the server sees, i.e. including any prefix the handler is mounted under.
+func SignRequest(r *http.Request, keyID string, secret []byte) error {
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	var random [16]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	ts := time.Now().Unix()
+	nonce := hex.EncodeToString(random[:])
+	r.Header.Set(HeaderKeyID, keyID)
+	r.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	r.Header.Set(HeaderNonce, nonce)
+	r.Header.Set(HeaderSignature, hex.EncodeToString(signature(secret, r.Method, r.URL.RequestURI(), ts, nonce, body)))
+	return nil
+}
+
+func signature(secret []byte, method, uri string, ts int64, nonce string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%s\n%x", method, uri, ts, nonce, bodyHash)
+	return mac.Sum(nil)
+}
+
+// readBody reads the request body and replaces it so that handlers can read it again.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	r.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(body) > maxBodySize {
+		return nil, fmt.Errorf("request body exceeds %d bytes", maxBodySize)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
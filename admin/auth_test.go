@@ -0,0 +1,172 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []cron.AuditEvent
+}
+
+func (r *recordingSink) WriteAudit(e cron.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+	return nil
+}
+
+var operatorSecret = []byte("operator-secret")
+
+func newAuthServer(t *testing.T) (*cron.Scheduler, *recordingSink, *httptest.Server) {
+	t.Helper()
+	s := cron.NewScheduler()
+	job, _ := cron.WrapJob("report", func() error { return nil })
+	if err := s.AddTask("0 9 * * *", job, cron.WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	sink := &recordingSink{}
+	s.WithAuditSink(sink)
+
+	auth := Authenticators{
+		BearerTokens{
+			"viewer-token":   {Name: "viewer", Role: RoleReadOnly},
+			"operator-token": {Name: "alice", Role: RoleOperator},
+		},
+		&HMACAuthenticator{Keys: map[string]HMACKey{
+			"ci": {Secret: operatorSecret, Principal: Principal{Name: "ci-bot", Role: RoleOperator}},
+		}},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/", http.StripPrefix("/admin", NewHandler(s, WithAuthenticator(auth))))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return s, sink, srv
+}
+
+func doAuth(t *testing.T, method, url, token string) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestAuth_BearerRoles(t *testing.T) {
+	_, sink, srv := newAuthServer(t)
+
+	cases := []struct {
+		method, path, token string
+		status              int
+	}{
+		{http.MethodGet, "/admin/tasks", "", http.StatusUnauthorized},
+		{http.MethodGet, "/admin/tasks", "wrong", http.StatusUnauthorized},
+		{http.MethodGet, "/admin/tasks", "viewer-token", http.StatusOK},
+		{http.MethodPost, "/admin/tasks/report/pause", "viewer-token", http.StatusForbidden},
+		{http.MethodPost, "/admin/tasks/report/pause", "operator-token", http.StatusOK},
+	}
+	for _, c := range cases {
+		if got := doAuth(t, c.method, srv.URL+c.path, c.token); got != c.status {
+			t.Errorf("%s %s with %q: status %d, want %d", c.method, c.path, c.token, got, c.status)
+		}
+	}
+
+	if len(sink.events) != 1 || sink.events[0].Actor != "alice" || sink.events[0].Action != cron.AuditPause {
+		t.Errorf("expected one pause by alice, got %+v", sink.events)
+	}
+}
+
+func TestAuth_HMAC(t *testing.T) {
+	s, sink, srv := newAuthServer(t)
+
+	newRequest := func() *http.Request {
+		req, err := http.NewRequest(http.MethodPatch, srv.URL+"/admin/tasks/report", strings.NewReader(`{"expression":"0 18 * * *"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req
+	}
+	send := func(req *http.Request) int {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	req := newRequest()
+	if err := SignRequest(req, "ci", operatorSecret); err != nil {
+		t.Fatal(err)
+	}
+	if status := send(req); status != http.StatusOK {
+		t.Fatalf("signed request: status %d", status)
+	}
+	if info, _ := s.Task("report"); info.Expression != "0 18 * * *" {
+		t.Errorf("update not applied: %+v", info)
+	}
+	if len(sink.events) != 1 || sink.events[0].Actor != "ci-bot" || sink.events[0].Before.Expression != "0 9 * * *" {
+		t.Errorf("unexpected audit events %+v", sink.events)
+	}
+
+	// A replayed request is rejected, and so is one without nonce.
+	req = newRequest()
+	_ = SignRequest(req, "ci", operatorSecret)
+	replay := newRequest()
+	replay.Header = req.Header.Clone()
+	if status := send(req); status != http.StatusOK {
+		t.Fatalf("signed request: status %d", status)
+	}
+	if status := send(replay); status != http.StatusUnauthorized {
+		t.Errorf("replayed request: status %d", status)
+	}
+	req = newRequest()
+	_ = SignRequest(req, "ci", operatorSecret)
+	req.Header.Del(HeaderNonce)
+	if status := send(req); status != http.StatusUnauthorized {
+		t.Errorf("missing nonce: status %d", status)
+	}
+	if len(sink.events) != 2 {
+		t.Errorf("expected only the two distinct requests to be applied, got %+v", sink.events)
+	}
+
+	// A tampered body, wrong secret or stale timestamp is rejected.
+	req = newRequest()
+	_ = SignRequest(req, "ci", operatorSecret)
+	req.Body = http.NoBody
+	req.ContentLength = 0
+	if status := send(req); status != http.StatusUnauthorized {
+		t.Errorf("tampered body: status %d", status)
+	}
+
+	req = newRequest()
+	_ = SignRequest(req, "ci", []byte("wrong"))
+	if status := send(req); status != http.StatusUnauthorized {
+		t.Errorf("wrong secret: status %d", status)
+	}
+
+	req = newRequest()
+	_ = SignRequest(req, "ci", operatorSecret)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10))
+	if status := send(req); status != http.StatusUnauthorized {
+		t.Errorf("stale timestamp: status %d", status)
+	}
+}
@@ -0,0 +1,160 @@
+package golitecron
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// SystemActor is the actor recorded for operations called directly on the Scheduler.
+const SystemActor = "system"
+
+// AuditAction names a mutating scheduler operation.
+type AuditAction string
+
+const (
+	AuditAdd     AuditAction = "add"
+	AuditRemove  AuditAction = "remove"
+	AuditUpdate  AuditAction = "update"
+	AuditPause   AuditAction = "pause"
+	AuditResume  AuditAction = "resume"
+	AuditTrigger AuditAction = "trigger"
+)
+
+// TaskDefinition is the audited definition of a task.
+type TaskDefinition struct {
+	Expression    string        `json:"expression"`
+	Location      string        `json:"location"`
+	Timeout       time.Duration `json:"timeout,omitempty"`
+	Retry         int           `json:"retry,omitempty"`
+	EnableSeconds bool          `json:"seconds,omitempty"`
+	EnableYears   bool          `json:"years,omitempty"`
+	Paused        bool          `json:"paused,omitempty"`
+}
+
+// AuditEvent records one mutating operation. Before is nil for additions,
+// After is nil for removals and triggers; both are nil if the task was not found.
+type AuditEvent struct {
+	Time   time.Time       `json:"time"`
+	Actor  string          `json:"actor"`
+	Action AuditAction     `json:"action"`
+	TaskID string          `json:"task_id"`
+	Before *TaskDefinition `json:"before,omitempty"`
+	After  *TaskDefinition `json:"after,omitempty"`
+	Error  string          `json:"error,omitempty"` // set if the operation failed
+}
+
+// AuditSink receives audit events. Implementations must be safe for concurrent use.
+type AuditSink interface {
+	WriteAudit(AuditEvent) error
+}
+
+// WithAuditSink sets the sink receiving an event for every mutating operation,
+// including failed ones. Must be called before Start(), and before adding tasks
+// if their addition should be audited too.
+func (s *Scheduler) WithAuditSink(sink AuditSink) {
+	s.auditSink = sink
+}
+
+// Controller performs mutating operations on behalf of an actor, who is recorded in the audit log.
+type Controller struct {
+	s     *Scheduler
+	actor string
+}
+
+// AsActor returns a Controller attributing operations to actor, e.g. an authenticated user name.
+func (s *Scheduler) AsActor(actor string) *Controller {
+	return &Controller{s: s, actor: actor}
+}
+
+// AddTask is Scheduler.AddTask performed by the controller's actor.
+func (c *Controller) AddTask(expr string, job Job, opts ...Option) error {
+	after, err := c.s.addTask(expr, job, opts...)
+	c.audit(AuditAdd, job.ID(), nil, after, err)
+	return err
+}
+
+// AddSchedule is Scheduler.AddSchedule performed by the controller's actor.
+func (c *Controller) AddSchedule(schedule *Schedule, job Job) error {
+	after, err := c.s.addSchedule(schedule, job)
+	c.audit(AuditAdd, job.ID(), nil, after, err)
+	return err
+}
+
+// RemoveTaskByID is Scheduler.RemoveTaskByID performed by the controller's actor.
+func (c *Controller) RemoveTaskByID(taskID string) bool {
+	return c.removeTask(&Task{ID: taskID})
+}
+
+func (c *Controller) removeTask(task *Task) bool {
+	before, ok := c.s.removeTask(task)
+	var err error
+	if !ok {
+		err = ErrTaskNotFound
+	}
+	c.audit(AuditRemove, task.ID, before, nil, err)
+	return ok
+}
+
+// UpdateTask is Scheduler.UpdateTask performed by the controller's actor.
+func (c *Controller) UpdateTask(taskID string, expr string, opts ...Option) error {
+	before, after, err := c.s.updateTask(taskID, expr, opts...)
+	c.audit(AuditUpdate, taskID, before, after, err)
+	return err
+}
+
+// PauseTask is Scheduler.PauseTask performed by the controller's actor.
+func (c *Controller) PauseTask(taskID string) error {
+	before, after, err := c.s.pauseTask(taskID)
+	c.audit(AuditPause, taskID, before, after, err)
+	return err
+}
+
+// ResumeTask is Scheduler.ResumeTask performed by the controller's actor.
+func (c *Controller) ResumeTask(taskID string) error {
+	before, after, err := c.s.resumeTask(taskID)
+	c.audit(AuditResume, taskID, before, after, err)
+	return err
+}
+
+// TriggerTask is Scheduler.TriggerTask performed by the controller's actor.
+func (c *Controller) TriggerTask(taskID string) error {
+	before, err := c.s.triggerTask(taskID)
+	c.audit(AuditTrigger, taskID, before, nil, err)
+	return err
+}
+
+func (c *Controller) audit(action AuditAction, taskID string, before, after *TaskDefinition, err error) {
+	sink := c.s.auditSink
+	if sink == nil {
+		return
+	}
+
+	event := AuditEvent{
+		Time:   time.Now(),
+		Actor:  c.actor,
+		Action: action,
+		TaskID: taskID,
+		Before: before,
+		After:  after,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	if err := sink.WriteAudit(event); err != nil {
+		c.s.logger.Printf("Failed to write audit event %s for task %s: %v\n", action, taskID, err)
+	}
+}
+
+// definition returns the audited definition of t. Caller must hold taskMu.
+func (t *Task) definition() *TaskDefinition {
+	p := t.CronParser
+	return &TaskDefinition{
+		Expression:    p.expr,
+		Location:      p.location.String(),
+		Timeout:       p.timeout,
+		Retry:         p.retry,
+		EnableSeconds: p.enableSeconds,
+		EnableYears:   p.enableYears,
+		Paused:        atomic.LoadInt32(&t.Paused) == 1,
+	}
+}
@@ -0,0 +1,125 @@
+package golitecron
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileAuditSink writes audit events to a file as JSON lines. When the file would
+// exceed MaxSize bytes it is rotated: path becomes path.1, path.1 becomes path.2,
+// and so on, keeping at most MaxBackups old files. A failed rotation is reported
+// by WriteAudit but does not stop the log: events keep being written, and a log
+// that could not be reopened is opened again on the next event.
+type FileAuditSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	closed bool
+}
+
+// NewFileAuditSink opens or creates the audit log at path, appending to existing content.
+// A maxSize of zero disables rotation.
+func NewFileAuditSink(path string, maxSize int64, maxBackups int) (*FileAuditSink, error) {
+	sink := &FileAuditSink{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := sink.open(); err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+// WriteAudit appends event as one JSON line, rotating the file first if needed.
+func (f *FileAuditSink) WriteAudit(event AuditEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return os.ErrClosed
+	}
+	var rotateErr error
+	if f.file != nil && f.maxSize > 0 && f.size > 0 && f.size+int64(len(line)) > f.maxSize {
+		rotateErr = f.rotate()
+	}
+	if f.file == nil {
+		if err := f.open(); err != nil {
+			return errors.Join(rotateErr, err)
+		}
+	}
+
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+	return errors.Join(rotateErr, err)
+}
+
+// Close closes the audit log.
+func (f *FileAuditSink) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.closed = true
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *FileAuditSink) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat audit log: %w", err)
+	}
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate shifts the backups and starts a new file. Every step is attempted even if
+// an earlier one fails, so that the log is reopened and later events are not lost;
+// f.file is nil only if reopening failed. Caller must hold mu.
+func (f *FileAuditSink) rotate() error {
+	var errs []error
+	if err := f.file.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close audit log: %w", err))
+	}
+	f.file = nil
+
+	if err := f.shiftBackups(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to rotate audit log: %w", err))
+	}
+	if err := f.open(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (f *FileAuditSink) shiftBackups() error {
+	if f.maxBackups <= 0 {
+		return os.Remove(f.path)
+	}
+	for i := f.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(f.path, f.path+".1")
+}
@@ -0,0 +1,195 @@
+package golitecron
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type memoryAuditSink struct {
+	mu     sync.Mutex
+	events []AuditEvent
+}
+
+func (m *memoryAuditSink) WriteAudit(e AuditEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, e)
+	return nil
+}
+
+// TestAudit_Operations tests that every mutating operation is audited with actor and definitions
+func TestAudit_Operations(t *testing.T) {
+	s := NewScheduler()
+	sink := &memoryAuditSink{}
+	s.WithAuditSink(sink)
+
+	job, _ := WrapJob("audited", func() error { return nil })
+	if err := s.AddTask("0 9 * * *", job, WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	alice := s.AsActor("alice")
+	if err := alice.PauseTask("audited"); err != nil {
+		t.Fatalf("PauseTask failed: %v", err)
+	}
+	if err := alice.ResumeTask("audited"); err != nil {
+		t.Fatalf("ResumeTask failed: %v", err)
+	}
+	if err := alice.UpdateTask("audited", "0 18 * * *"); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	if err := alice.TriggerTask("audited"); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("expected ErrNotRunning, got %v", err)
+	}
+	if !alice.RemoveTaskByID("audited") {
+		t.Fatal("RemoveTaskByID failed")
+	}
+	if err := alice.PauseTask("audited"); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("expected ErrTaskNotFound, got %v", err)
+	}
+
+	want := []struct {
+		actor  string
+		action AuditAction
+		before string
+		after  string
+		failed bool
+	}{
+		{SystemActor, AuditAdd, "", "0 9 * * *", false},
+		{"alice", AuditPause, "0 9 * * *", "0 9 * * *", false},
+		{"alice", AuditResume, "0 9 * * *", "0 9 * * *", false},
+		{"alice", AuditUpdate, "0 9 * * *", "0 18 * * *", false},
+		{"alice", AuditTrigger, "0 18 * * *", "", true},
+		{"alice", AuditRemove, "0 18 * * *", "", false},
+		{"alice", AuditPause, "", "", true},
+	}
+	if len(sink.events) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(sink.events), sink.events)
+	}
+	expr := func(d *TaskDefinition) string {
+		if d == nil {
+			return ""
+		}
+		return d.Expression
+	}
+	for i, w := range want {
+		e := sink.events[i]
+		if e.Actor != w.actor || e.Action != w.action || e.TaskID != "audited" ||
+			expr(e.Before) != w.before || expr(e.After) != w.after || (e.Error != "") != w.failed || e.Time.IsZero() {
+			t.Errorf("event %d: got %+v, want %+v", i, e, w)
+		}
+	}
+	if pause := sink.events[1]; pause.Before.Paused || !pause.After.Paused {
+		t.Errorf("pause event should record the paused flag: %+v", pause)
+	}
+	if add := sink.events[0]; add.After.Location != "UTC" {
+		t.Errorf("unexpected location in %+v", add.After)
+	}
+}
+
+// TestFileAuditSink_Rotation tests JSON-lines output and size-based rotation
+func TestFileAuditSink_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileAuditSink(path, 300, 2)
+	if err != nil {
+		t.Fatalf("NewFileAuditSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	for i := 0; i < 10; i++ {
+		event := AuditEvent{Time: time.Now(), Actor: "bob", Action: AuditPause, TaskID: "rotating"}
+		if err := sink.WriteAudit(event); err != nil {
+			t.Fatalf("WriteAudit failed: %v", err)
+		}
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatalf("expected %s to exist: %v", name, err)
+		}
+		if info.Size() > 300 {
+			t.Errorf("%s exceeds max size: %d bytes", name, info.Size())
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected at most 2 backups, got %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e AuditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Actor != "bob" || e.Action != AuditPause {
+			t.Errorf("unexpected line %q: %v", scanner.Text(), err)
+		}
+	}
+
+	sink.Close()
+	if err := sink.WriteAudit(AuditEvent{}); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expected os.ErrClosed after Close, got %v", err)
+	}
+}
+
+// TestFileAuditSink_RotationFailure tests that events are written even when rotation fails
+func TestFileAuditSink_RotationFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileAuditSink(path, 100, 1)
+	if err != nil {
+		t.Fatalf("NewFileAuditSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	// A directory in place of the backup makes shifting fail.
+	if err := os.MkdirAll(filepath.Join(path+".1", "busy"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	event := AuditEvent{Time: time.Now(), Actor: "bob", Action: AuditPause, TaskID: "rotating"}
+	var failed bool
+	for i := 0; i < 3; i++ {
+		if err := sink.WriteAudit(event); err != nil {
+			failed = true
+		}
+	}
+	if !failed {
+		t.Fatal("expected the failed rotation to be reported")
+	}
+	if n := countLines(t, path); n != 3 {
+		t.Errorf("expected all 3 events in the log, got %d", n)
+	}
+
+	// A failed close still starts a new file and writes the event to it.
+	if err := os.RemoveAll(path + ".1"); err != nil {
+		t.Fatal(err)
+	}
+	sink.file.Close()
+	if err := sink.WriteAudit(event); err == nil || !strings.Contains(err.Error(), "failed to close audit log") {
+		t.Errorf("expected the failed close to be reported, got %v", err)
+	}
+	if n := countLines(t, path); n != 1 {
+		t.Errorf("expected the event in the new log, got %d lines", n)
+	}
+	if n := countLines(t, path+".1"); n != 3 {
+		t.Errorf("expected the old log as backup, got %d lines", n)
+	}
+}
+
+func countLines(t *testing.T, name string) int {
+	t.Helper()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return strings.Count(string(data), "\n")
+}
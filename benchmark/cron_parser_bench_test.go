@@ -11,7 +11,7 @@ import (
 var benchExpressions = map[string]string{
 	"Simple":   "0 0 * * *",          // Every day at midnight
 	"Medium":   "*/15 9-17 * * 1-5",  // Every 15min during work hours on weekdays
-	"Complex":  "0 0,30 9-17 1,15 *", // Specific times on 1st and 15th
+	"Complex":  "0,30 9-17 1,15 * *", // Specific times on 1st and 15th
 	"Minutely": "* * * * *",          // Every minute
 	"Hourly":   "0 * * * *",          // Every hour
 }
@@ -194,6 +194,62 @@ func BenchmarkNext_Sequential_1000(b *testing.B) {
 	}
 }
 
+// A year of minutely fires, by repeated Next and by the Between iterator.
+func BenchmarkNext_YearOfMinutes(b *testing.B) {
+	parser := createParserViaScheduler(b, benchExpressions["Minutely"])
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for t := parser.Next(start); t.Before(end); t = parser.Next(t) {
+		}
+	}
+}
+
+func BenchmarkBetween_YearOfMinutes(b *testing.B) {
+	parser := createParserViaScheduler(b, benchExpressions["Minutely"])
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for range parser.Between(start, end) {
+		}
+	}
+}
+
+// Matches tests one instant against the compiled fields.
+func BenchmarkMatches_Medium(b *testing.B) {
+	parser := createParserViaScheduler(b, benchExpressions["Medium"])
+	now := time.Date(2026, 3, 10, 9, 45, 0, 0, time.Local)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = parser.Matches(now)
+	}
+}
+
+func BenchmarkMatches_DayTokens(b *testing.B) {
+	parser := createParserViaScheduler(b, "0 0 L,15W * 5L,1#2")
+	now := time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = parser.Matches(now)
+	}
+}
+
+func BenchmarkNext_DayTokens(b *testing.B) {
+	parser := createParserViaScheduler(b, "0 0 L,15W * 5L,1#2")
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = parser.Next(now)
+	}
+}
+
 // ============================================================================
 // Parallel Benchmarks - Thread Safety
 // ============================================================================
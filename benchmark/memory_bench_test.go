@@ -49,6 +49,25 @@ func BenchmarkMemory_Parse_RobfigCron(b *testing.B) {
 	}
 }
 
+// BenchmarkMemory_Retained_Schedules reports the heap retained per parsed schedule,
+// which dominates the cost of large task registries.
+func BenchmarkMemory_Retained_Schedules(b *testing.B) {
+	const count = 100000
+	exprs := []string{"*/15 9-17 * * 1-5", "0 0 L * *", "30 2 * * 0", "0,30 9-17 1,15 * *"}
+	schedules := make([]*golitecron.Schedule, count)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		before := getMemStats()
+		for j := range schedules {
+			schedules[j], _ = golitecron.Parse(exprs[j%len(exprs)])
+		}
+		after := getMemStats()
+		b.ReportMetric(float64(int64(after.HeapAlloc)-int64(before.HeapAlloc))/count, "B/schedule")
+		clear(schedules)
+	}
+}
+
 // ============================================================================
 // Storage Backend Memory Analysis
 // ============================================================================
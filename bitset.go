@@ -0,0 +1,71 @@
+package golitecron
+
+import "math/bits"
+
+// bitset is a set of values in [0, 63]: value v is present when bit v is set.
+type bitset uint64
+
+func newBitset(vals map[int]struct{}) bitset {
+	var b bitset
+	for v := range vals {
+		b = b.add(v)
+	}
+	return b
+}
+
+func (b bitset) add(v int) bitset {
+	return b | 1<<uint(v)
+}
+
+func (b bitset) has(v int) bool {
+	return v >= 0 && v < 64 && b&(1<<uint(v)) != 0
+}
+
+func (b bitset) count() int {
+	return bits.OnesCount64(uint64(b))
+}
+
+// next returns the smallest value >= v.
+func (b bitset) next(v int) (int, bool) {
+	if v >= 64 {
+		return 0, false
+	}
+	rest := uint64(b) >> uint(max(v, 0)) << uint(max(v, 0))
+	if rest == 0 {
+		return 0, false
+	}
+	return bits.TrailingZeros64(rest), true
+}
+
+// prev returns the largest value <= v.
+func (b bitset) prev(v int) (int, bool) {
+	if v < 0 {
+		return 0, false
+	}
+	rest := uint64(b) & (1<<uint(min(v, 63)+1) - 1)
+	if rest == 0 {
+		return 0, false
+	}
+	return 63 - bits.LeadingZeros64(rest), true
+}
+
+// first returns the smallest value, or 0 if the set is empty.
+func (b bitset) first() int {
+	v, _ := b.next(0)
+	return v
+}
+
+// last returns the largest value, or 0 if the set is empty.
+func (b bitset) last() int {
+	v, _ := b.prev(63)
+	return v
+}
+
+// values returns the values in ascending order.
+func (b bitset) values() []int {
+	vals := make([]int, 0, b.count())
+	for rest := uint64(b); rest != 0; rest &= rest - 1 {
+		vals = append(vals, bits.TrailingZeros64(rest))
+	}
+	return vals
+}
@@ -0,0 +1,99 @@
+package golitecron
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestBitset(t *testing.T) {
+	var b bitset
+	for _, v := range []int{0, 5, 31, 63} {
+		b = b.add(v)
+	}
+	if got := b.values(); !slices.Equal(got, []int{0, 5, 31, 63}) || b.count() != 4 {
+		t.Fatalf("unexpected values %v", got)
+	}
+	if !b.has(5) || b.has(6) || b.has(-1) || b.has(64) {
+		t.Error("unexpected membership")
+	}
+	if b.first() != 0 || b.last() != 63 {
+		t.Errorf("first/last = %d/%d", b.first(), b.last())
+	}
+
+	tests := []struct {
+		v              int
+		next, prev     int
+		nextOK, prevOK bool
+	}{
+		{-3, 0, 0, true, false},
+		{0, 0, 0, true, true},
+		{1, 5, 0, true, true},
+		{5, 5, 5, true, true},
+		{32, 63, 31, true, true},
+		{63, 63, 63, true, true},
+		{64, 0, 63, false, true},
+	}
+	for _, tt := range tests {
+		if n, ok := b.next(tt.v); n != tt.next || ok != tt.nextOK {
+			t.Errorf("next(%d) = %d, %v", tt.v, n, ok)
+		}
+		if p, ok := b.prev(tt.v); p != tt.prev || ok != tt.prevOK {
+			t.Errorf("prev(%d) = %d, %v", tt.v, p, ok)
+		}
+	}
+
+	var empty bitset
+	if _, ok := empty.next(0); ok {
+		t.Error("next on empty set")
+	}
+	if _, ok := empty.prev(63); ok {
+		t.Error("prev on empty set")
+	}
+	if got := empty.values(); len(got) != 0 {
+		t.Errorf("unexpected values %v", got)
+	}
+}
+
+// TestCronParser_CompiledDayTokens tests that day tokens land in their own sets
+func TestCronParser_CompiledDayTokens(t *testing.T) {
+	p, err := newCronParser("0 0 1,L,L-2,LW,15W * 0,5L,7L,1#2")
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	if got := p.dayOfMonth.values(); !slices.Equal(got, []int{1}) {
+		t.Errorf("dayOfMonth = %v", got)
+	}
+	if got := p.lastDays.values(); !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("lastDays = %v", got)
+	}
+	if got := p.nearestWeekdays.values(); !slices.Equal(got, []int{15}) || !p.lastWeekday {
+		t.Errorf("nearestWeekdays = %v, lastWeekday = %v", got, p.lastWeekday)
+	}
+	if got := p.dayOfWeek.values(); !slices.Equal(got, []int{0}) {
+		t.Errorf("dayOfWeek = %v", got)
+	}
+	if got := p.lastWeekdays.values(); !slices.Equal(got, []int{0, 5}) {
+		t.Errorf("lastWeekdays = %v", got)
+	}
+	if got := p.nthWeekdays.values(); !slices.Equal(got, []int{8}) {
+		t.Errorf("nthWeekdays = %v", got)
+	}
+}
+
+// TestCronParser_ZeroAlloc tests that Next, Prev and Matches do not allocate
+func TestCronParser_ZeroAlloc(t *testing.T) {
+	p, err := newCronParser("0 */15 9-17 L,15W * 1-5,5L,1#2 2020-2030", WithSeconds(), WithYears(), WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	now := time.Date(2026, 3, 10, 9, 45, 0, 0, time.UTC)
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = p.Matches(now)
+		_ = p.Next(now)
+		_ = p.Prev(now)
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocations per run, want 0", allocs)
+	}
+}
@@ -0,0 +1,314 @@
+// Command cronx inspects cron expressions with the GoLiteCron parser.
+//
+// Usage:
+//
+//	cronx next [-n 10] [-tz zone] [-from time] [-seconds] [-years] [-dialect d] <expr>
+//	cronx explain [-seconds] [-years] [-dialect d] <expr>
+//	cronx validate [-seconds] [-years] [-dialect d] <expr>
+//	cronx validate -config tasks.yaml
+//	cronx diff [-tz zone] [-from time] [-horizon 7d] [-seconds] [-years] [-dialect d] <old-expr> <new-expr>
+//
+// Flags go before the expression; quote expressions to protect them from the shell.
+// diff exits with status 1 if the fire times differ, like diff(1); validate exits
+// with status 1 if the expression or config is invalid, printing the problems to
+// standard error. Other errors exit with status 2.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+const timeLayout = "2006-01-02 15:04:05 MST Mon"
+
+var (
+	// errDiffer signals a successful diff that found differences.
+	errDiffer = errors.New("fire times differ")
+	// errInvalid signals that validate found problems, which it has already printed.
+	errInvalid = errors.New("invalid")
+)
+
+func main() {
+	os.Exit(exitCode(os.Stderr, run(os.Stdout, os.Stderr, os.Args[1:])))
+}
+
+// exitCode returns the exit status for the result of run, printing unreported errors to stderr.
+func exitCode(stderr io.Writer, err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, errDiffer), errors.Is(err, errInvalid):
+		return 1
+	case errors.Is(err, flag.ErrHelp):
+		return 2
+	default:
+		fmt.Fprintln(stderr, "cronx:", err)
+		return 2
+	}
+}
+
+func usage(w io.Writer) {
+	fmt.Fprint(w, `Usage:
+  cronx next [-n 10] [-tz zone] [-from time] [-seconds] [-years] [-dialect d] <expr>
+  cronx explain [-seconds] [-years] [-dialect d] <expr>
+  cronx validate [-seconds] [-years] [-dialect d] <expr>
+  cronx validate -config tasks.yaml
+  cronx diff [-tz zone] [-from time] [-horizon 7d] [-seconds] [-years] [-dialect d] <old-expr> <new-expr>
+
+Flags go before the expression. Run "cronx <command> -h" for the flags of a command.
+`)
+}
+
+// run runs the command in args, writing results to w and diagnostics to stderr.
+func run(w, stderr io.Writer, args []string) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return flag.ErrHelp
+	}
+
+	switch args[0] {
+	case "next":
+		return runNext(w, args[1:])
+	case "explain":
+		return runExplain(w, args[1:])
+	case "validate":
+		return runValidate(w, stderr, args[1:])
+	case "diff":
+		return runDiff(w, args[1:])
+	case "help", "-h", "-help", "--help":
+		usage(w)
+		return nil
+	default:
+		usage(stderr)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// fieldFlags are the flags shared by all commands.
+type fieldFlags struct {
+	seconds bool
+	years   bool
+	dialect string
+	tz      string
+	from    string
+}
+
+func newFlagSet(name string, f *fieldFlags, withTime bool) *flag.FlagSet {
+	fs := flag.NewFlagSet("cronx "+name, flag.ContinueOnError)
+	fs.BoolVar(&f.seconds, "seconds", false, "expression has a leading seconds field")
+	fs.BoolVar(&f.years, "years", false, "expression has a trailing years field")
+	fs.StringVar(&f.dialect, "dialect", "standard", "expression syntax: standard, auto or quartz")
+	if withTime {
+		fs.StringVar(&f.tz, "tz", "Local", "time zone, e.g. UTC or Europe/Berlin")
+		fs.StringVar(&f.from, "from", "", "start time in RFC 3339 (default now)")
+	}
+	return fs
+}
+
+func (f *fieldFlags) options() ([]cron.Option, error) {
+	var opts []cron.Option
+	if f.seconds {
+		opts = append(opts, cron.WithSeconds())
+	}
+	if f.years {
+		opts = append(opts, cron.WithYears())
+	}
+	dialect, err := cron.ParseDialect(f.dialect)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, cron.WithDialect(dialect))
+	if f.tz != "" {
+		loc, err := time.LoadLocation(f.tz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %w", f.tz, err)
+		}
+		opts = append(opts, cron.WithLocation(loc))
+	}
+	return opts, nil
+}
+
+func (f *fieldFlags) start() (time.Time, error) {
+	if f.from == "" {
+		return time.Now(), nil
+	}
+	t, err := time.Parse(time.RFC3339, f.from)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid -from time: %w", err)
+	}
+	return t, nil
+}
+
+// expression joins the remaining arguments, so that unquoted fields also work.
+func expression(fs *flag.FlagSet) (string, error) {
+	if fs.NArg() == 0 {
+		return "", fmt.Errorf("missing cron expression")
+	}
+	return strings.Join(fs.Args(), " "), nil
+}
+
+func runNext(w io.Writer, args []string) error {
+	var f fieldFlags
+	fs := newFlagSet("next", &f, true)
+	n := fs.Int("n", 10, "number of fire times")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	expr, err := expression(fs)
+	if err != nil {
+		return err
+	}
+	opts, err := f.options()
+	if err != nil {
+		return err
+	}
+	from, err := f.start()
+	if err != nil {
+		return err
+	}
+
+	times, err := cron.FireTimes(expr, from, *n, opts...)
+	if err != nil {
+		return err
+	}
+	for _, t := range times {
+		fmt.Fprintln(w, t.Format(timeLayout))
+	}
+	if len(times) < *n {
+		fmt.Fprintf(w, "(no further fire times)\n")
+	}
+	return nil
+}
+
+func runExplain(w io.Writer, args []string) error {
+	var f fieldFlags
+	fs := newFlagSet("explain", &f, false)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	expr, err := expression(fs)
+	if err != nil {
+		return err
+	}
+	opts, err := f.options()
+	if err != nil {
+		return err
+	}
+
+	desc, err := cron.Explain(expr, opts...)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(w, desc)
+	return nil
+}
+
+func runValidate(w, stderr io.Writer, args []string) error {
+	var f fieldFlags
+	fs := newFlagSet("validate", &f, false)
+	configPath := fs.String("config", "", "validate every task of a YAML or JSON config instead")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *configPath != "" {
+		config, err := cron.LoadConfig(*configPath)
+		if err != nil {
+			return err
+		}
+		if err := cron.ValidateConfig(config); err != nil {
+			fmt.Fprintf(stderr, "%s:\n%v\n", *configPath, err)
+			return errInvalid
+		}
+		fmt.Fprintf(w, "%s: %d tasks OK\n", *configPath, len(config.Tasks))
+		return nil
+	}
+
+	expr, err := expression(fs)
+	if err != nil {
+		return err
+	}
+	opts, err := f.options()
+	if err != nil {
+		return err
+	}
+	if err := cron.Validate(expr, opts...); err != nil {
+		fmt.Fprintln(stderr, err)
+		return errInvalid
+	}
+	fmt.Fprintln(w, "OK")
+	return nil
+}
+
+func runDiff(w io.Writer, args []string) error {
+	var f fieldFlags
+	fs := newFlagSet("diff", &f, true)
+	horizonFlag := fs.String("horizon", "7d", "time span to compare, e.g. 36h or 30d")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("diff takes two quoted expressions, got %d arguments", fs.NArg())
+	}
+	horizon, err := parseHorizon(*horizonFlag)
+	if err != nil {
+		return err
+	}
+	opts, err := f.options()
+	if err != nil {
+		return err
+	}
+	from, err := f.start()
+	if err != nil {
+		return err
+	}
+
+	oldExpr, newExpr := fs.Arg(0), fs.Arg(1)
+	removed, added, err := cron.DiffFireTimes(oldExpr, newExpr, from, from.Add(horizon), opts...)
+	if err != nil {
+		return err
+	}
+
+	// Print both lists merged in time order.
+	for i, j := 0, 0; i < len(removed) || j < len(added); {
+		if j == len(added) || i < len(removed) && removed[i].Before(added[j]) {
+			fmt.Fprintf(w, "- %s\n", removed[i].Format(timeLayout))
+			i++
+		} else {
+			fmt.Fprintf(w, "+ %s\n", added[j].Format(timeLayout))
+			j++
+		}
+	}
+	fmt.Fprintf(w, "%d removed, %d added within %s from %s\n",
+		len(removed), len(added), *horizonFlag, from.Format(time.RFC3339))
+
+	if len(removed) > 0 || len(added) > 0 {
+		return errDiffer
+	}
+	return nil
+}
+
+// parseHorizon parses a duration, additionally accepting whole days such as "30d".
+func parseHorizon(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid horizon %q", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"valid.yaml": `
+tasks:
+  - id: report
+    cron_expr: "0 9 * * 1-5"
+    command: "true"
+`,
+		"valid.json":   `{"tasks": [{"id": "report", "cron_expr": "0 9 * * 1-5", "command": "true"}]}`,
+		"invalid.yaml": "tasks:\n  - id: broken\n    cron_expr: \"61 * * * *\"\n    command: \"true\"\n",
+		"broken.json":  `{"tasks": [`,
+		"tasks.toml":   "",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		args           []string
+		code           int
+		stdout, stderr string
+	}{
+		{[]string{"validate", "0 9 * * 1-5"}, 0, "OK", ""},
+		{[]string{"validate", "-seconds", "0 0 9 * * 1-5"}, 0, "OK", ""},
+		{[]string{"validate", "61 * * * *"}, 1, "", "61"},
+		{[]string{"validate", "-config", filepath.Join(dir, "valid.yaml")}, 0, "1 tasks OK", ""},
+		{[]string{"validate", "-config", filepath.Join(dir, "valid.json")}, 0, "1 tasks OK", ""},
+		{[]string{"validate", "-config", filepath.Join(dir, "invalid.yaml")}, 1, "", "broken"},
+		{[]string{"validate", "-config", filepath.Join(dir, "broken.json")}, 2, "", "cronx:"},
+		{[]string{"validate", "-config", filepath.Join(dir, "missing.yaml")}, 2, "", "no such file"},
+		{[]string{"validate", "-config", filepath.Join(dir, "tasks.toml")}, 2, "", "unsupported config file"},
+	}
+	for _, c := range cases {
+		var stdout, stderr strings.Builder
+		code := exitCode(&stderr, run(&stdout, &stderr, c.args))
+		if code != c.code {
+			t.Errorf("%q: exit status %d, want %d (stdout %q, stderr %q)", c.args, code, c.code, stdout.String(), stderr.String())
+		}
+		if c.stdout != "" && !strings.Contains(stdout.String(), c.stdout) || c.stdout == "" && stdout.Len() != 0 {
+			t.Errorf("%q: stdout %q, want %q", c.args, stdout.String(), c.stdout)
+		}
+		if c.stderr != "" && !strings.Contains(stderr.String(), c.stderr) || c.stderr == "" && stderr.Len() != 0 {
+			t.Errorf("%q: stderr %q, want %q", c.args, stderr.String(), c.stderr)
+		}
+	}
+}
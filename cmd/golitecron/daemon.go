@@ -0,0 +1,118 @@
+//go:build unix
+
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+// daemon keeps a scheduler in sync with a config file.
+type daemon struct {
+	scheduler *cron.Scheduler
+	logger    cron.Logger
+	path      string
+	shell     string
+
+	tasks map[string]cron.TaskConfig // currently scheduled definitions
+}
+
+func newDaemon(s *cron.Scheduler, logger cron.Logger, path, shell string) *daemon {
+	return &daemon{
+		scheduler: s,
+		logger:    logger,
+		path:      path,
+		shell:     shell,
+		tasks:     make(map[string]cron.TaskConfig),
+	}
+}
+
+// checkConfig validates every task of config without scheduling anything. Besides
+// the task definitions, it checks that no task would replace one added over the
+// control socket, which reload could only find out after removing tasks.
+func (d *daemon) checkConfig(config *cron.Config) error {
+	scratch := cron.NewScheduler()
+	for _, tc := range config.Tasks {
+		if err := d.addTask(scratch, tc); err != nil {
+			return err
+		}
+		if _, managed := d.tasks[tc.ID]; !managed {
+			if _, scheduled := d.scheduler.Task(tc.ID); scheduled {
+				return fmt.Errorf("task %s is already scheduled outside the config", tc.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// reload reads the config and applies the differences to the scheduler. The whole
+// config is validated before anything is changed, so nothing is changed if it is
+// invalid. Running executions of changed or removed tasks are allowed to finish.
+func (d *daemon) reload() error {
+	config, err := cron.LoadConfig(d.path)
+	if err != nil {
+		return err
+	}
+	if err := d.checkConfig(config); err != nil {
+		return err
+	}
+
+	next := make(map[string]cron.TaskConfig, len(config.Tasks))
+	for _, tc := range config.Tasks {
+		next[tc.ID] = tc
+	}
+
+	var added, updated, removed int
+	var errs []error
+	for id := range d.tasks {
+		if _, ok := next[id]; !ok {
+			d.scheduler.RemoveTaskByID(id)
+			delete(d.tasks, id)
+			removed++
+		}
+	}
+	for _, tc := range config.Tasks {
+		old, exists := d.tasks[tc.ID]
+		if _, scheduled := d.scheduler.Task(tc.ID); exists && old == tc && scheduled {
+			continue // unchanged, and not removed over the control socket
+		}
+		if exists {
+			// Replace rather than update, so that settings removed from the config are reset too.
+			d.scheduler.RemoveTaskByID(tc.ID)
+			delete(d.tasks, tc.ID)
+		}
+		if err := d.addTask(d.scheduler, tc); err != nil {
+			// Validated above; only possible if the clock moved past a one-off
+			// schedule. The other tasks are still applied.
+			errs = append(errs, err)
+			continue
+		}
+		d.tasks[tc.ID] = tc
+		if exists {
+			updated++
+		} else {
+			added++
+		}
+	}
+
+	d.logger.Printf("Loaded %s: %d tasks (%d added, %d updated, %d removed)\n",
+		d.path, len(d.tasks), added, updated, removed)
+	return errors.Join(errs...)
+}
+
+func (d *daemon) addTask(s *cron.Scheduler, tc cron.TaskConfig) error {
+	if tc.ID == "" || tc.CronExpr == "" || tc.Command == "" {
+		return fmt.Errorf("task config is missing required fields: id, cron_expr or command")
+	}
+	opts, err := tc.Options()
+	if err != nil {
+		return err
+	}
+	job := &shellJob{id: tc.ID, shell: d.shell, command: tc.Command, logger: d.logger}
+	if err := s.AddTask(tc.CronExpr, job, opts...); err != nil {
+		return fmt.Errorf("failed to add task %s: %w", tc.ID, err)
+	}
+	return nil
+}
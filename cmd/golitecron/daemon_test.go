@@ -0,0 +1,183 @@
+//go:build unix
+
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+func writeConfig(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDaemon_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cron.yaml")
+	writeConfig(t, path, `
+tasks:
+  - id: keep
+    cron_expr: "0 * * * *"
+    command: "true"
+  - id: change
+    cron_expr: "0 9 * * *"
+    command: "true"
+    timeout: 1m
+  - id: drop
+    cron_expr: "0 0 * * *"
+    command: "true"
+`)
+	s := cron.NewScheduler()
+	d := newDaemon(s, log.New(os.Stderr, "", 0), path, "/bin/sh")
+	if err := d.reload(); err != nil {
+		t.Fatalf("initial load failed: %v", err)
+	}
+	if len(s.Tasks()) != 3 {
+		t.Fatalf("expected 3 tasks, got %+v", s.Tasks())
+	}
+	keep, _ := s.Task("keep")
+	_ = s.PauseTask("keep") // an unchanged task must not be replaced
+
+	writeConfig(t, path, `
+tasks:
+  - id: keep
+    cron_expr: "0 * * * *"
+    command: "true"
+  - id: change
+    cron_expr: "0 18 * * *"
+    command: "true"
+  - id: new
+    cron_expr: "*/5 * * * *"
+    command: "true"
+`)
+	if err := d.reload(); err != nil {
+		t.Fatalf("reload failed: %v", err)
+	}
+
+	tasks := s.Tasks()
+	if len(tasks) != 3 || tasks[0].ID != "change" || tasks[1].ID != "keep" || tasks[2].ID != "new" {
+		t.Fatalf("unexpected tasks after reload %+v", tasks)
+	}
+	if tasks[0].Expression != "0 18 * * *" || tasks[0].Timeout != 0 {
+		t.Errorf("changed task should follow the new config, got %+v", tasks[0])
+	}
+	if tasks[1].Status != cron.TaskStatusPaused || tasks[1].Expression != keep.Expression {
+		t.Errorf("unchanged task should be left alone, got %+v", tasks[1])
+	}
+
+	// An invalid config leaves the tasks untouched.
+	writeConfig(t, path, `
+tasks:
+  - id: keep
+    cron_expr: "0 * * * *"
+    command: "true"
+  - id: broken
+    cron_expr: "61 * * * *"
+    command: "true"
+`)
+	if err := d.reload(); err == nil {
+		t.Fatal("expected reload of invalid config to fail")
+	}
+	if len(s.Tasks()) != 3 {
+		t.Errorf("invalid config should not change tasks, got %+v", s.Tasks())
+	}
+
+	// So does a task added over the control socket that the config would replace.
+	manual, _ := cron.WrapJob("manual", func() error { return nil })
+	if err := s.AddTask("0 12 * * *", manual); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig(t, path, `
+tasks:
+  - id: manual
+    cron_expr: "0 * * * *"
+    command: "true"
+`)
+	if err := d.reload(); err == nil || !strings.Contains(err.Error(), "outside the config") {
+		t.Fatalf("expected reload to reject replacing a task added outside the config, got %v", err)
+	}
+	if len(s.Tasks()) != 4 {
+		t.Errorf("rejected config should not change tasks, got %+v", s.Tasks())
+	}
+}
+
+func TestDaemon_CheckConfig(t *testing.T) {
+	d := newDaemon(cron.NewScheduler(), log.New(os.Stderr, "", 0), "", "/bin/sh")
+	cases := []struct {
+		config cron.Config
+		want   string
+	}{
+		{cron.Config{Tasks: []cron.TaskConfig{{ID: "a", CronExpr: "* * * * *"}}}, "missing required fields"},
+		{cron.Config{Tasks: []cron.TaskConfig{{ID: "a", CronExpr: "* * * * *", Command: "x", Timeout: "soon"}}}, "invalid timeout"},
+		{cron.Config{Tasks: []cron.TaskConfig{
+			{ID: "a", CronExpr: "* * * * *", Command: "x"},
+			{ID: "a", CronExpr: "* * * * *", Command: "y"},
+		}}, "already exists"},
+	}
+	for _, c := range cases {
+		if err := d.checkConfig(&c.config); err == nil || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("%+v: expected error containing %q, got %v", c.config, c.want, err)
+		}
+	}
+}
+
+func TestShellJob(t *testing.T) {
+	var out strings.Builder
+	job := &shellJob{id: "echo", shell: "/bin/sh", command: "echo hello; exit 3", logger: log.New(&out, "", 0)}
+	err := job.Execute(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "exit status 3") {
+		t.Errorf("expected exit status error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "hello") {
+		t.Errorf("expected output to be logged, got %q", out.String())
+	}
+
+	// A timeout terminates the command and the processes it started.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	job = &shellJob{id: "sleep", shell: "/bin/sh", command: "sleep 10 & wait", logger: log.New(&out, "", 0)}
+	start := time.Now()
+	if err := job.Execute(ctx); err == nil {
+		t.Error("expected cancelled command to fail")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("command was not terminated promptly: %s", elapsed)
+	}
+
+	// Output beyond the limit is dropped.
+	out.Reset()
+	job = &shellJob{id: "noisy", shell: "/bin/sh", command: "yes | head -c 1000000", logger: log.New(&out, "", 0)}
+	if err := job.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "... (truncated)") || out.Len() > maxLoggedOutput+100 {
+		t.Errorf("expected truncated output, got %d bytes", out.Len())
+	}
+}
+
+func TestBoundedBuffer(t *testing.T) {
+	b := &boundedBuffer{limit: 10}
+	for _, s := range []string{"a", "éééé", "éééé"} { // the limit splits the fifth é
+		if n, err := b.Write([]byte(s)); n != len(s) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v", s, n, err)
+		}
+	}
+	if got := b.String(); got != "aéééé" || !b.truncated || len(b.buf) != 10 {
+		t.Errorf("got %q (truncated %v, %d bytes kept)", got, b.truncated, len(b.buf))
+	}
+
+	b = &boundedBuffer{limit: 10}
+	b.Write([]byte("éééé"))
+	if got := b.String(); got != "éééé" || b.truncated {
+		t.Errorf("got %q (truncated %v)", got, b.truncated)
+	}
+}
@@ -0,0 +1,122 @@
+//go:build unix
+
+// Command golitecron is a cron daemon running shell commands on GoLiteCron schedules,
+// with support for seconds, years, L/W and per-task timeouts, retries and locations.
+//
+// Usage:
+//
+//	golitecron -config /etc/golitecron.yaml [-syslog] [-shell /bin/sh] [-control path] [-check]
+//
+// Tasks are read from a YAML or JSON config (see cron.TaskConfig); each task runs
+// its command with the shell:
+//
+//	tasks:
+//	  - id: backup
+//	    cron_expr: "0 30 2 * * *"
+//	    enable_seconds: true
+//	    command: /usr/local/bin/backup.sh
+//	    timeout: 1h
+//	    retry: 2
+//	    location: Europe/Berlin
+//
+// SIGHUP reloads the config: added, changed and removed tasks are applied while
+// running commands finish undisturbed; an invalid config is logged and ignored.
+// SIGTERM and SIGINT stop scheduling and wait for running commands to finish.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"log/syslog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+	"github.com/hansir-hsj/GoLiteCron/control"
+)
+
+func main() {
+	configPath := flag.String("config", "/etc/golitecron.yaml", "path of the YAML or JSON config")
+	useSyslog := flag.Bool("syslog", false, "log to syslog instead of stdout")
+	shell := flag.String("shell", "/bin/sh", "shell used to run task commands")
+	controlPath := flag.String("control", "", "serve the control socket for golitecronctl at this path")
+	check := flag.Bool("check", false, "validate the config and exit")
+	flag.Parse()
+
+	logger, err := newLogger(*useSyslog)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "golitecron:", err)
+		os.Exit(1)
+	}
+
+	if err := run(logger, *configPath, *shell, *controlPath, *check); err != nil {
+		logger.Printf("golitecron: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func newLogger(useSyslog bool) (*log.Logger, error) {
+	if !useSyslog {
+		return log.New(os.Stdout, "", log.LstdFlags), nil
+	}
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, "golitecron")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return log.New(w, "", 0), nil
+}
+
+func run(logger *log.Logger, configPath, shell, controlPath string, check bool) error {
+	s := cron.NewScheduler()
+	s.WithLogger(logger)
+	d := newDaemon(s, logger, configPath, shell)
+
+	if check {
+		config, err := cron.LoadConfig(configPath)
+		if err != nil {
+			return err
+		}
+		if err := d.checkConfig(config); err != nil {
+			return err
+		}
+		logger.Printf("%s: %d tasks OK\n", configPath, len(config.Tasks))
+		return nil
+	}
+
+	// Register signals before loading so that an early SIGHUP is not lost.
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP, syscall.SIGTERM, syscall.SIGINT)
+
+	if err := d.reload(); err != nil {
+		return err
+	}
+
+	if controlPath != "" {
+		srv, err := control.Listen(s, controlPath)
+		if err != nil {
+			return err
+		}
+		defer srv.Close()
+	}
+
+	s.Start()
+	logger.Printf("Scheduler started\n")
+
+	for sig := range signals {
+		if sig == syscall.SIGHUP {
+			if err := d.reload(); err != nil {
+				logger.Printf("Reload failed, keeping current tasks: %v\n", err)
+			}
+			continue
+		}
+
+		logger.Printf("Received %s, waiting for running tasks to finish\n", sig)
+		signal.Stop(signals)
+		s.Stop()
+		logger.Printf("Scheduler stopped\n")
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+//go:build unix
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+// killGracePeriod is how long a cancelled command may take to exit after SIGTERM.
+const killGracePeriod = 5 * time.Second
+
+// maxLoggedOutput bounds the command output written to the log per execution.
+const maxLoggedOutput = 4096
+
+// shellJob runs a command with the configured shell.
+type shellJob struct {
+	id      string
+	shell   string
+	command string
+	logger  cron.Logger
+}
+
+func (j *shellJob) ID() string {
+	return j.id
+}
+
+// Execute runs the command in its own process group, so that a timeout or
+// shutdown terminates the children it started as well.
+func (j *shellJob) Execute(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, j.shell, "-c", j.command)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+	cmd.WaitDelay = killGracePeriod
+
+	output := &boundedBuffer{limit: maxLoggedOutput}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	start := time.Now()
+	err := cmd.Run()
+	if out := strings.TrimSpace(output.String()); out != "" {
+		if output.truncated {
+			out += "... (truncated)"
+		}
+		j.logger.Printf("Task %s output:\n%s\n", j.id, out)
+	}
+	if err != nil {
+		return fmt.Errorf("command %q failed after %s: %w", j.command, time.Since(start).Round(time.Millisecond), err)
+	}
+	return nil
+}
+
+// boundedBuffer keeps the first limit bytes written to it and discards the rest, so
+// that a command printing without end does not grow the daemon's memory.
+type boundedBuffer struct {
+	buf       []byte
+	limit     int
+	truncated bool
+}
+
+func (b *boundedBuffer) Write(p []byte) (int, error) {
+	kept := p
+	if room := b.limit - len(b.buf); len(p) > room {
+		kept = p[:max(room, 0)]
+		b.truncated = true
+	}
+	b.buf = append(b.buf, kept...)
+	return len(p), nil
+}
+
+// String returns the output kept, without a rune cut in half by the limit.
+func (b *boundedBuffer) String() string {
+	out := b.buf
+	if b.truncated {
+		for i := len(out) - 1; i >= 0 && i > len(out)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(out[i]) {
+				if !utf8.FullRune(out[i:]) {
+					out = out[:i]
+				}
+				break
+			}
+		}
+	}
+	return string(out)
+}
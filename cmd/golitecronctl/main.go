@@ -0,0 +1,164 @@
+// Command golitecronctl manages the tasks of a process serving the GoLiteCron
+// control socket (see package control).
+//
+// Usage:
+//
+//	golitecronctl [-socket path] [-json] <command> [task-id]
+//
+// Commands:
+//
+//	list              list tasks
+//	describe <id>     show a task with upcoming runs and recent executions
+//	pause <id>        pause a task
+//	resume <id>       resume a paused task
+//	trigger <id>      run a task now
+//	remove <id>       remove a task
+//	dump-state        print the full scheduler state as JSON
+//
+// The socket path defaults to $GOLITECRON_SOCKET, or golitecron.sock in the temp directory.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/hansir-hsj/GoLiteCron/control"
+)
+
+const timeLayout = "2006-01-02 15:04:05 MST"
+
+func main() {
+	socket := flag.String("socket", control.DefaultSocketPath(), "path of the control socket")
+	asJSON := flag.Bool("json", false, "print responses as JSON")
+	flag.Usage = usage
+	flag.Parse()
+
+	if err := run(os.Stdout, *socket, *asJSON, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "golitecronctl:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: golitecronctl [flags] <command> [task-id]
+
+Commands:
+  list              list tasks
+  describe <id>     show a task with upcoming runs and recent executions
+  pause <id>        pause a task
+  resume <id>       resume a paused task
+  trigger <id>      run a task now
+  remove <id>       remove a task
+  dump-state        print the full scheduler state as JSON
+
+Flags:
+`)
+	flag.PrintDefaults()
+}
+
+func run(w io.Writer, socket string, asJSON bool, args []string) error {
+	if len(args) == 0 {
+		flag.Usage()
+		return fmt.Errorf("missing command")
+	}
+	req := control.Request{Command: args[0]}
+	switch req.Command {
+	case control.CommandList, control.CommandDumpState:
+		if len(args) != 1 {
+			return fmt.Errorf("%s takes no arguments", req.Command)
+		}
+	default:
+		if len(args) != 2 {
+			return fmt.Errorf("usage: golitecronctl %s <task-id>", req.Command)
+		}
+		req.TaskID = args[1]
+	}
+
+	client, err := control.Dial(socket)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case asJSON || resp.State != nil:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(resp)
+	case resp.Tasks != nil || req.Command == control.CommandList:
+		printTasks(w, resp.Tasks)
+	case resp.Task != nil:
+		printTask(w, *resp.Task)
+	default:
+		fmt.Fprintf(w, "%s %s: ok\n", req.Command, req.TaskID)
+	}
+	return nil
+}
+
+func printTasks(w io.Writer, tasks []control.Task) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tEXPRESSION\tSTATUS\tNEXT RUN\tRUNS\tFAILURES\tLAST ERROR")
+	for _, t := range tasks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			t.ID, t.Expression, t.Status, formatTime(t.NextRun), t.Runs, t.Failures, t.LastError)
+	}
+	tw.Flush()
+}
+
+func printTask(w io.Writer, t control.Task) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%s\n", t.ID)
+	fmt.Fprintf(tw, "Expression:\t%s\n", t.Expression)
+	if t.Resolved != "" {
+		fmt.Fprintf(tw, "Resolved:\t%s\n", t.Resolved)
+	}
+	fmt.Fprintf(tw, "Schedule:\t%s (%s)\n", t.Description, t.Location)
+	fmt.Fprintf(tw, "Status:\t%s\n", t.Status)
+	fmt.Fprintf(tw, "Previous run:\t%s\n", formatTime(t.PrevRun))
+	fmt.Fprintf(tw, "Runs:\t%d (%d failed)\n", t.Runs, t.Failures)
+	if t.LastError != "" {
+		fmt.Fprintf(tw, "Last error:\t%s\n", t.LastError)
+	}
+	tw.Flush()
+
+	if len(t.Upcoming) > 0 {
+		fmt.Fprintln(w, "\nUpcoming:")
+		for _, next := range t.Upcoming {
+			fmt.Fprintf(w, "  %s\n", next.Format(timeLayout))
+		}
+	}
+
+	if len(t.Recent) > 0 {
+		fmt.Fprintln(w, "\nRecent executions:")
+		tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "  STARTED\tDURATION\tKIND\tRESULT")
+		for _, e := range t.Recent {
+			kind, result := "scheduled", "ok"
+			if e.Triggered {
+				kind = "manual"
+			}
+			if e.Error != "" {
+				result = e.Error
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", e.Started.Format(timeLayout), e.Duration.Round(time.Millisecond), kind, result)
+		}
+		tw.Flush()
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(timeLayout)
+}
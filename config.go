@@ -1,5 +1,10 @@
 package golitecron
 
+import (
+	"fmt"
+	"time"
+)
+
 type TaskConfig struct {
 	ID            string `yaml:"id" json:"id"`
 	CronExpr      string `yaml:"cron_expr" json:"cron_expr"`
@@ -9,8 +14,49 @@ type TaskConfig struct {
 	EnableSeconds bool   `yaml:"enable_seconds" json:"enable_seconds"`
 	EnableYears   bool   `yaml:"enable_years" json:"enable_years"`
 	FuncName      string `yaml:"func_name" json:"func_name"`
+	// Dialect is the expression syntax: "standard" (default), "auto" or "quartz".
+	Dialect string `yaml:"dialect" json:"dialect"`
+	// Command is a shell command run by the golitecron daemon instead of a registered
+	// function. LoadTasksFromConfig ignores it.
+	Command string `yaml:"command" json:"command"`
 }
 
 type Config struct {
 	Tasks []TaskConfig `yaml:"tasks" json:"tasks"`
 }
+
+// Options returns the task options configured by tc.
+func (tc TaskConfig) Options() ([]Option, error) {
+	var opts []Option
+	if tc.EnableSeconds {
+		opts = append(opts, WithSeconds())
+	}
+	if tc.EnableYears {
+		opts = append(opts, WithYears())
+	}
+	if tc.Dialect != "" {
+		dialect, err := ParseDialect(tc.Dialect)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dialect for task %s: %w", tc.ID, err)
+		}
+		opts = append(opts, WithDialect(dialect))
+	}
+	if tc.Timeout != "" {
+		timeout, err := time.ParseDuration(tc.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout duration %q for task %s: %w", tc.Timeout, tc.ID, err)
+		}
+		opts = append(opts, WithTimeout(timeout))
+	}
+	if tc.Retry > 0 {
+		opts = append(opts, WithRetry(tc.Retry))
+	}
+	if tc.Location != "" {
+		loc, err := time.LoadLocation(tc.Location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load location %s: %w", tc.Location, err)
+		}
+		opts = append(opts, WithLocation(loc))
+	}
+	return opts, nil
+}
@@ -2,11 +2,27 @@ package golitecron
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
+// LoadConfig reads a config file as YAML or JSON depending on its extension:
+// .yaml, .yml or .json.
+func LoadConfig(path string) (*Config, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return LoadFromJSON(path)
+	case ".yaml", ".yml":
+		return LoadFromYAML(path)
+	default:
+		return nil, fmt.Errorf("unsupported config file %s: expected .yaml, .yml or .json", path)
+	}
+}
+
 func LoadFromYAML(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -177,3 +177,29 @@ func TestLoadFromYaml_WithAllFields(t *testing.T) {
 		t.Errorf("expected America/New_York location, got %s", task.Location)
 	}
 }
+
+func TestLoadConfig_Extension(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.yaml": "tasks:\n  - id: yaml\n",
+		"config.YML":  "tasks:\n  - id: yml\n",
+		"config.json": `{"tasks": [{"id": "json"}]}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create temp file: %v", err)
+		}
+		config, err := LoadConfig(path)
+		if err != nil {
+			t.Fatalf("LoadConfig(%s) failed: %v", name, err)
+		}
+		if len(config.Tasks) != 1 || config.Tasks[0].ID == "" {
+			t.Errorf("LoadConfig(%s): unexpected tasks %+v", name, config.Tasks)
+		}
+	}
+
+	if _, err := LoadConfig(filepath.Join(dir, "config.toml")); err == nil {
+		t.Error("expected unsupported extension to fail")
+	}
+}
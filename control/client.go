@@ -0,0 +1,112 @@
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds dialing and each request made by a Client.
+const DefaultTimeout = 10 * time.Second
+
+// Client is a connection to a control socket. It is safe for concurrent use;
+// requests are sent one at a time.
+type Client struct {
+	mu   sync.Mutex
+	conn net.Conn
+	dec  *json.Decoder
+	enc  *json.Encoder
+}
+
+// Dial connects to the control socket at path.
+func Dial(path string) (*Client, error) {
+	conn, err := net.DialTimeout("unix", path, DefaultTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", path, err)
+	}
+	return &Client{
+		conn: conn,
+		dec:  json.NewDecoder(bufio.NewReader(conn)),
+		enc:  json.NewEncoder(conn),
+	}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Do sends req and returns the server's response. A response with OK false is
+// returned as an error.
+func (c *Client) Do(req Request) (Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.conn.SetDeadline(time.Now().Add(DefaultTimeout)); err != nil {
+		return Response{}, err
+	}
+	if err := c.enc.Encode(req); err != nil {
+		return Response{}, fmt.Errorf("failed to send request: %w", err)
+	}
+	var resp Response
+	if err := c.dec.Decode(&resp); err != nil {
+		return Response{}, fmt.Errorf("failed to read response: %w", err)
+	}
+	if !resp.OK {
+		return resp, errors.New(resp.Error)
+	}
+	return resp, nil
+}
+
+// List returns all tasks.
+func (c *Client) List() ([]Task, error) {
+	resp, err := c.Do(Request{Command: CommandList})
+	return resp.Tasks, err
+}
+
+// Describe returns a task with its upcoming runs and recent executions.
+func (c *Client) Describe(taskID string) (Task, error) {
+	return c.taskCommand(CommandDescribe, taskID)
+}
+
+// Pause pauses a task.
+func (c *Client) Pause(taskID string) (Task, error) {
+	return c.taskCommand(CommandPause, taskID)
+}
+
+// Resume resumes a paused task.
+func (c *Client) Resume(taskID string) (Task, error) {
+	return c.taskCommand(CommandResume, taskID)
+}
+
+// Trigger runs a task now.
+func (c *Client) Trigger(taskID string) (Task, error) {
+	return c.taskCommand(CommandTrigger, taskID)
+}
+
+// Remove removes a task.
+func (c *Client) Remove(taskID string) error {
+	_, err := c.Do(Request{Command: CommandRemove, TaskID: taskID})
+	return err
+}
+
+// DumpState returns the full scheduler state.
+func (c *Client) DumpState() (*State, error) {
+	resp, err := c.Do(Request{Command: CommandDumpState})
+	return resp.State, err
+}
+
+func (c *Client) taskCommand(cmd, taskID string) (Task, error) {
+	resp, err := c.Do(Request{Command: cmd, TaskID: taskID})
+	if err != nil {
+		return Task{}, err
+	}
+	if resp.Task == nil {
+		return Task{}, fmt.Errorf("%s: response has no task", cmd)
+	}
+	return *resp.Task, nil
+}
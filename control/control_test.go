@@ -0,0 +1,190 @@
+package control
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+// socketPath returns a short socket path; t.TempDir can exceed the Unix socket path limit.
+func socketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "glc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return filepath.Join(dir, "ctl.sock")
+}
+
+func newTestServer(t *testing.T) (*cron.Scheduler, *Client, string) {
+	t.Helper()
+	s := cron.NewScheduler()
+	job, _ := cron.WrapJob("report", func() error { return nil })
+	if err := s.AddTask("30 9 * * 1-5", job, cron.WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	path := socketPath(t)
+	srv, err := Listen(s, path)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	t.Cleanup(func() { srv.Close() })
+
+	client, err := Dial(path)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return s, client, path
+}
+
+func TestControl_ListAndDescribe(t *testing.T) {
+	_, client, path := newTestServer(t)
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("expected socket with mode 0600, got %v, %v", info, err)
+	}
+
+	tasks, err := client.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != "report" || tasks[0].Status != "scheduled" || tasks[0].NextRun == nil {
+		t.Errorf("unexpected tasks %+v", tasks)
+	}
+
+	task, err := client.Describe("report")
+	if err != nil {
+		t.Fatalf("Describe failed: %v", err)
+	}
+	if task.Description != "At 09:30, on Monday through Friday" || len(task.Upcoming) != upcomingRuns {
+		t.Errorf("unexpected task %+v", task)
+	}
+
+	if _, err := client.Describe("missing"); err == nil || !strings.Contains(err.Error(), "task not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if _, err := client.Do(Request{Command: "bogus"}); err == nil {
+		t.Error("expected error for unknown command")
+	}
+	if _, err := client.Do(Request{Command: CommandPause}); err == nil {
+		t.Error("expected error for missing task ID")
+	}
+}
+
+func TestControl_Operations(t *testing.T) {
+	s, client, _ := newTestServer(t)
+
+	task, err := client.Pause("report")
+	if err != nil || task.Status != "paused" {
+		t.Fatalf("Pause: %+v, %v", task, err)
+	}
+	task, err = client.Resume("report")
+	if err != nil || task.Status != "scheduled" {
+		t.Fatalf("Resume: %+v, %v", task, err)
+	}
+	if _, err := client.Trigger("report"); err == nil || !strings.Contains(err.Error(), "not running") {
+		t.Errorf("expected trigger to fail on a stopped scheduler, got %v", err)
+	}
+
+	state, err := client.DumpState()
+	if err != nil || state.Running || state.Storage != "heap" || len(state.Tasks) != 1 {
+		t.Errorf("unexpected state %+v, %v", state, err)
+	}
+
+	if err := client.Remove("report"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if err := client.Remove("report"); err == nil {
+		t.Error("expected second removal to fail")
+	}
+	if len(s.Tasks()) != 0 {
+		t.Errorf("expected no tasks, got %+v", s.Tasks())
+	}
+}
+
+func TestControl_RawProtocol(t *testing.T) {
+	_, _, path := newTestServer(t)
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	for _, line := range []string{`{"command":"list"}`, `not json`} {
+		if _, err := conn.Write([]byte(line + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line == "not json" && !strings.Contains(resp, `"invalid request`) {
+			t.Errorf("expected invalid request error, got %s", resp)
+		}
+		if line != "not json" && !strings.HasPrefix(resp, `{"ok":true,"tasks":[{"id":"report"`) {
+			t.Errorf("unexpected list response %s", resp)
+		}
+	}
+}
+
+func TestControl_StaleSocket(t *testing.T) {
+	s := cron.NewScheduler()
+	path := socketPath(t)
+
+	srv, err := Listen(s, path)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	// The socket is bound in a private directory, which is gone once it is moved to path.
+	if entries, err := os.ReadDir(filepath.Dir(path)); err != nil || len(entries) != 1 {
+		t.Errorf("expected only the socket next to it, got %v, %v", entries, err)
+	}
+	if _, err := Listen(s, path); err == nil || !strings.Contains(err.Error(), "in use") {
+		t.Errorf("expected in-use error, got %v", err)
+	}
+	srv.Close()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected socket to be removed on Close, got %v", err)
+	}
+
+	// Simulate a crashed process leaving its socket behind.
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.(*net.UnixListener).SetUnlinkOnClose(false)
+	l.Close()
+
+	srv, err = Listen(s, path)
+	if err != nil {
+		t.Fatalf("expected stale socket to be replaced: %v", err)
+	}
+	srv.Close()
+}
+
+func TestDefaultSocketPath(t *testing.T) {
+	t.Setenv(SocketEnv, "")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	if got := DefaultSocketPath(); got != "/run/user/1000/golitecron.sock" {
+		t.Errorf("expected the socket in the runtime directory, got %s", got)
+	}
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if got := DefaultSocketPath(); got != filepath.Join(os.TempDir(), "golitecron.sock") {
+		t.Errorf("expected the socket in the temp directory, got %s", got)
+	}
+	t.Setenv(SocketEnv, "/var/run/cron.sock")
+	if got := DefaultSocketPath(); got != "/var/run/cron.sock" {
+		t.Errorf("expected $%s, got %s", SocketEnv, got)
+	}
+}
@@ -0,0 +1,143 @@
+// Package control serves scheduler control operations over a Unix domain socket.
+//
+// The protocol is line-delimited JSON: a client writes one Request per line and
+// reads one Response per line, over as many requests as it likes per connection.
+//
+//	{"command":"pause","task_id":"report"}
+//	{"ok":true,"task":{"id":"report",...}}
+//
+// Commands are list, describe, pause, resume, trigger, remove and dump-state.
+// Access is controlled by the socket file permissions, which Listen sets to 0600.
+package control
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+// Commands understood by the server.
+const (
+	CommandList      = "list"
+	CommandDescribe  = "describe"
+	CommandPause     = "pause"
+	CommandResume    = "resume"
+	CommandTrigger   = "trigger"
+	CommandRemove    = "remove"
+	CommandDumpState = "dump-state"
+)
+
+// Request is one line sent by the client.
+type Request struct {
+	Command string `json:"command"`
+	TaskID  string `json:"task_id,omitempty"`
+}
+
+// Response is one line sent by the server. Error is set if OK is false.
+type Response struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	Tasks []Task `json:"tasks,omitempty"` // list
+	Task  *Task  `json:"task,omitempty"`  // describe, pause, resume, trigger
+	State *State `json:"state,omitempty"` // dump-state
+}
+
+// Task describes a task. Upcoming and Recent are only filled by describe and dump-state.
+type Task struct {
+	ID          string      `json:"id"`
+	Expression  string      `json:"expression"`
+	Resolved    string      `json:"resolved,omitempty"` // set when H tokens or a macro were resolved
+	Description string      `json:"description"`
+	Location    string      `json:"location"`
+	Status      string      `json:"status"`
+	PrevRun     *time.Time  `json:"prev_run,omitempty"`
+	NextRun     *time.Time  `json:"next_run,omitempty"`
+	Runs        uint64      `json:"runs"`
+	Failures    uint64      `json:"failures"`
+	LastError   string      `json:"last_error,omitempty"`
+	Upcoming    []time.Time `json:"upcoming,omitempty"`
+	Recent      []Execution `json:"recent,omitempty"`
+}
+
+// Execution is the JSON representation of a cron.ExecutionRecord.
+type Execution struct {
+	Scheduled *time.Time    `json:"scheduled,omitempty"`
+	Started   time.Time     `json:"started"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+	Triggered bool          `json:"triggered,omitempty"`
+}
+
+// State is a full dump of the scheduler for debugging.
+type State struct {
+	Time        time.Time   `json:"time"`
+	Running     bool        `json:"running"`
+	LastTick    *time.Time  `json:"last_tick,omitempty"`
+	LoopStalled bool        `json:"loop_stalled"`
+	Storage     string      `json:"storage"`
+	InFlight    int         `json:"in_flight"`
+	StuckTasks  []StuckTask `json:"stuck_tasks,omitempty"`
+	Tasks       []Task      `json:"tasks"`
+}
+
+// StuckTask is the JSON representation of a cron.StuckTask.
+type StuckTask struct {
+	TaskID      string        `json:"task_id"`
+	ExecutionID uint64        `json:"execution_id"`
+	Started     time.Time     `json:"started"`
+	RunningFor  time.Duration `json:"running_for"`
+}
+
+func newTask(info cron.TaskInfo) Task {
+	t := Task{
+		ID:          info.ID,
+		Expression:  info.Expression,
+		Description: info.Description,
+		Location:    info.Location.String(),
+		Status:      info.Status.String(),
+		Runs:        info.Stats.Runs,
+		Failures:    info.Stats.Failures,
+		LastError:   info.Stats.LastError,
+	}
+	if info.ResolvedExpression != info.Expression {
+		t.Resolved = info.ResolvedExpression
+	}
+	if !info.PrevRunTime.IsZero() {
+		t.PrevRun = &info.PrevRunTime
+	}
+	if !info.NextRunTime.IsZero() {
+		t.NextRun = &info.NextRunTime
+	}
+	return t
+}
+
+func newExecution(rec cron.ExecutionRecord) Execution {
+	e := Execution{
+		Started:   rec.Started,
+		Duration:  rec.Duration(),
+		Error:     rec.Error,
+		Triggered: rec.Triggered,
+	}
+	if !rec.Scheduled.IsZero() {
+		e.Scheduled = &rec.Scheduled
+	}
+	return e
+}
+
+// SocketEnv is the environment variable golitecronctl reads the socket path from.
+const SocketEnv = "GOLITECRON_SOCKET"
+
+// DefaultSocketPath returns $GOLITECRON_SOCKET, or golitecron.sock in the user's
+// runtime directory $XDG_RUNTIME_DIR, falling back to the temp directory.
+func DefaultSocketPath() string {
+	if path := os.Getenv(SocketEnv); path != "" {
+		return path
+	}
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "golitecron.sock")
+}
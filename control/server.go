@@ -0,0 +1,268 @@
+package control
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+// Actor is the audit actor recorded for operations performed over the socket.
+const Actor = "control"
+
+const (
+	upcomingRuns = 5
+	// maxLineSize bounds a request line.
+	maxLineSize = 64 << 10
+)
+
+// Server serves the control protocol for a scheduler.
+type Server struct {
+	scheduler *cron.Scheduler
+	listener  net.Listener
+	path      string
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// Listen creates the socket at path, readable and writable only by the current
+// user, and serves s on it until Close. A stale socket left by a crashed process is replaced.
+func Listen(s *cron.Scheduler, path string) (*Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+
+	l, err := listenPrivate(path)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &Server{
+		scheduler: s,
+		listener:  l,
+		path:      path,
+		conns:     make(map[net.Conn]struct{}),
+	}
+	srv.wg.Add(1)
+	go srv.serve()
+	return srv, nil
+}
+
+// listenPrivate listens on a socket at path that other users can never connect to.
+// The socket is created with the umask's permissions, so it is bound inside a new
+// 0700 directory next to path, restricted to 0600 and only then moved to path.
+func listenPrivate(path string) (*net.UnixListener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".ctl") // short, for the socket path limit
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	defer os.RemoveAll(dir)
+
+	tmp := filepath.Join(dir, "s")
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmp, Net: "unix"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	l.SetUnlinkOnClose(false) // Close removes the socket at path instead
+	if err := os.Chmod(tmp, 0o600); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		l.Close()
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// removeStaleSocket removes a socket file nobody is listening on.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return fmt.Errorf("%s is in use by another process", path)
+	}
+	return os.Remove(path)
+}
+
+// Close stops accepting connections, closes open ones and removes the socket file.
+func (srv *Server) Close() error {
+	srv.mu.Lock()
+	if srv.closed {
+		srv.mu.Unlock()
+		return nil
+	}
+	srv.closed = true
+	err := srv.listener.Close()
+	if rmErr := os.Remove(srv.path); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) && err == nil {
+		err = rmErr
+	}
+	for conn := range srv.conns {
+		conn.Close()
+	}
+	srv.mu.Unlock()
+
+	srv.wg.Wait()
+	return err
+}
+
+func (srv *Server) serve() {
+	defer srv.wg.Done()
+	for {
+		conn, err := srv.listener.Accept()
+		if err != nil {
+			return // listener closed
+		}
+
+		srv.mu.Lock()
+		if srv.closed {
+			srv.mu.Unlock()
+			conn.Close()
+			return
+		}
+		srv.conns[conn] = struct{}{}
+		srv.wg.Add(1)
+		srv.mu.Unlock()
+
+		go srv.handleConn(conn)
+	}
+}
+
+func (srv *Server) handleConn(conn net.Conn) {
+	defer func() {
+		srv.mu.Lock()
+		delete(srv.conns, conn)
+		srv.mu.Unlock()
+		conn.Close()
+		srv.wg.Done()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), maxLineSize)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var req Request
+		resp := Response{}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp.Error = "invalid request: " + err.Error()
+		} else {
+			resp = srv.handle(req)
+		}
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+func (srv *Server) handle(req Request) Response {
+	switch req.Command {
+	case CommandList:
+		infos := srv.scheduler.Tasks()
+		tasks := make([]Task, 0, len(infos))
+		for _, info := range infos {
+			tasks = append(tasks, newTask(info))
+		}
+		return Response{OK: true, Tasks: tasks}
+	case CommandDumpState:
+		return Response{OK: true, State: srv.state()}
+	case CommandDescribe, CommandPause, CommandResume, CommandTrigger, CommandRemove:
+		return srv.handleTask(req)
+	default:
+		return Response{Error: fmt.Sprintf("unknown command %q", req.Command)}
+	}
+}
+
+// handleTask performs a command on a single task and responds with its new state.
+func (srv *Server) handleTask(req Request) Response {
+	if req.TaskID == "" {
+		return Response{Error: "task_id is required"}
+	}
+
+	c := srv.scheduler.AsActor(Actor)
+	var err error
+	switch req.Command {
+	case CommandPause:
+		err = c.PauseTask(req.TaskID)
+	case CommandResume:
+		err = c.ResumeTask(req.TaskID)
+	case CommandTrigger:
+		err = c.TriggerTask(req.TaskID)
+	case CommandRemove:
+		if !c.RemoveTaskByID(req.TaskID) {
+			return Response{Error: fmt.Sprintf("%s: %s", cron.ErrTaskNotFound, req.TaskID)}
+		}
+		return Response{OK: true}
+	}
+	if err != nil {
+		return Response{Error: err.Error()}
+	}
+
+	task, ok := srv.describe(req.TaskID)
+	if !ok {
+		return Response{Error: fmt.Sprintf("%s: %s", cron.ErrTaskNotFound, req.TaskID)}
+	}
+	return Response{OK: true, Task: &task}
+}
+
+// describe returns a task with its upcoming runs and recent executions.
+func (srv *Server) describe(taskID string) (Task, bool) {
+	info, ok := srv.scheduler.Task(taskID)
+	if !ok {
+		return Task{}, false
+	}
+	return srv.detailed(info), true
+}
+
+func (srv *Server) detailed(info cron.TaskInfo) Task {
+	t := newTask(info)
+	t.Upcoming = srv.scheduler.UpcomingRuns(info.ID, upcomingRuns)
+	for _, rec := range srv.scheduler.RecentExecutions(info.ID) {
+		t.Recent = append(t.Recent, newExecution(rec))
+	}
+	return t
+}
+
+func (srv *Server) state() *State {
+	health := srv.scheduler.Health()
+	state := &State{
+		Time:        time.Now(),
+		Running:     health.Running,
+		LoopStalled: health.LoopStalled,
+		Storage:     health.StorageType.String(),
+		InFlight:    health.InFlight,
+	}
+	if !health.LastTick.IsZero() {
+		state.LastTick = &health.LastTick
+	}
+	for _, st := range health.StuckTasks {
+		state.StuckTasks = append(state.StuckTasks, StuckTask{
+			TaskID:      st.TaskID,
+			ExecutionID: st.ExecutionID,
+			Started:     st.Started,
+			RunningFor:  st.RunningFor,
+		})
+	}
+	for _, info := range srv.scheduler.Tasks() {
+		state.Tasks = append(state.Tasks, srv.detailed(info))
+	}
+	return state
+}
@@ -2,6 +2,7 @@ package golitecron
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -20,6 +21,27 @@ const (
 	Years
 )
 
+func (f FieldType) String() string {
+	switch f {
+	case Seconds:
+		return "second"
+	case Minutes:
+		return "minute"
+	case Hours:
+		return "hour"
+	case DayOfMonth:
+		return "day-of-month"
+	case Months:
+		return "month"
+	case DayOfWeek:
+		return "day-of-week"
+	case Years:
+		return "year"
+	default:
+		return fmt.Sprintf("FieldType(%d)", int(f))
+	}
+}
+
 type parseRule struct {
 	field     FieldType
 	min       int
@@ -28,13 +50,24 @@ type parseRule struct {
 }
 
 type CronParser struct {
-	seconds    map[int]struct{}
-	minutes    map[int]struct{}
-	hours      map[int]struct{}
-	dayOfMonth map[int]struct{}
-	months     map[int]struct{}
-	dayOfWeek  map[int]struct{}
-	years      map[int]struct{}
+	expr   string               // expression as given, before macro expansion
+	fields map[FieldType]string // raw field text after macro expansion
+
+	// Compiled fields for Next and Matches.
+	seconds    bitset
+	minutes    bitset
+	hours      bitset
+	dayOfMonth bitset // plain days 1-31
+	months     bitset
+	dayOfWeek  bitset // plain weekdays 0-6
+	years      []int  // sorted; years do not fit a bitset
+
+	// Day tokens that depend on the month.
+	lastDays        bitset // L-n sets bit n; L is L-0
+	nearestWeekdays bitset // nW sets bit n
+	lastWeekday     bool   // LW
+	lastWeekdays    bitset // nL sets bit n, Sunday as 0
+	nthWeekdays     bitset // n#k sets bit 7*(k-1)+n
 
 	enableSeconds bool
 	enableYears   bool
@@ -42,12 +75,30 @@ type CronParser struct {
 	timeout       time.Duration
 	retry         int
 
-	// Pre-sorted slices for Next() field-jumping algorithm.
-	sortedSeconds []int
-	sortedMinutes []int
-	sortedHours   []int
-	sortedMonths  []int
-	sortedYears   []int
+	// SLA bounds measured from the scheduled fire time; zero disables a check.
+	slaStartDelay time.Duration
+	slaCompletion time.Duration
+
+	// Expected run duration; executions exceeding it are flagged by the watchdog.
+	expectedDuration time.Duration
+
+	// Free-form labels for filtering, e.g. in event streams.
+	tags []string
+
+	// Seed for resolving H tokens; the task ID unless set with WithHashSeed.
+	hashSeed string
+
+	// Syntax of the expression; see WithDialect. Fields are stored in standard
+	// syntax, with Quartz days of the week renumbered.
+	dialect Dialect
+
+	// Location set by options, which a CRON_TZ= or TZ= prefix of the expression
+	// overrides; updates inherit it rather than the prefix.
+	optionLocation *time.Location
+
+	// Handling of wall times skipped or repeated by DST transitions.
+	dstGap     DSTGap
+	dstOverlap DSTOverlap
 
 	// Track wildcards for dayOfMonth/dayOfWeek OR logic.
 	dayOfMonthWildcard bool
@@ -68,6 +119,24 @@ func WithYears() Option {
 	}
 }
 
+// WithoutSeconds disables the seconds field, e.g. when UpdateTask changes a task
+// created WithSeconds to a 5-field expression.
+func WithoutSeconds() Option {
+	return func(p *CronParser) {
+		p.enableSeconds = false
+	}
+}
+
+// WithoutYears disables the years field, e.g. when UpdateTask changes a task
+// created WithYears to an expression without years.
+func WithoutYears() Option {
+	return func(p *CronParser) {
+		p.enableYears = false
+	}
+}
+
+// WithLocation sets the time zone fire times are computed in; the default is
+// time.Local. A CRON_TZ= or TZ= prefix in the expression takes precedence.
 func WithLocation(loc *time.Location) Option {
 	return func(p *CronParser) {
 		p.location = loc
@@ -92,6 +161,66 @@ func WithRetry(retry int) Option {
 	}
 }
 
+// WithSLA sets the maximum tolerated delay between a task's scheduled fire time
+// and its actual start, and between the scheduled fire time and completion.
+// A zero duration disables the corresponding check.
+func WithSLA(maxStartDelay, maxCompletion time.Duration) Option {
+	return func(p *CronParser) {
+		p.slaStartDelay = max(maxStartDelay, 0)
+		p.slaCompletion = max(maxCompletion, 0)
+	}
+}
+
+// WithExpectedDuration sets how long an execution is expected to take. The watchdog
+// flags executions running longer, which helps catch jobs that hang without a timeout.
+func WithExpectedDuration(d time.Duration) Option {
+	return func(p *CronParser) {
+		p.expectedDuration = max(d, 0)
+	}
+}
+
+// WithTags labels a task, e.g. by team or system. Tags are reported in TaskInfo
+// and events and can be used to filter event streams.
+func WithTags(tags ...string) Option {
+	return func(p *CronParser) {
+		p.tags = append([]string(nil), tags...)
+	}
+}
+
+// resolved returns the fields of the expression after macro expansion and H
+// resolution, e.g. "37 * * * *" for "H * * * *". In the Quartz dialect the result
+// is written in Quartz syntax, also for macros: with a seconds field, ? in one of
+// the day fields and Quartz weekday numbering. It parses again in the same dialect.
+func (p *CronParser) resolved() string {
+	quartz := p.dialect == DialectQuartz
+	fields := make([]string, 0, len(p.fields)+1)
+	if quartz && !p.enableSeconds {
+		fields = append(fields, "0") // a macro, expanded without seconds
+	}
+	for _, rule := range defaultRules {
+		f, ok := p.fields[rule.field]
+		if !ok {
+			continue
+		}
+		if quartz {
+			switch {
+			case rule.field == DayOfMonth && f == "*" && p.fields[DayOfWeek] != "*" && p.fields[DayOfWeek] != "?":
+				f = "?"
+			case rule.field == DayOfWeek && f == "*" && p.fields[DayOfMonth] != "?":
+				f = "?"
+			case rule.field == DayOfWeek:
+				f = quartzNumbering(f)
+			}
+		}
+		fields = append(fields, f)
+	}
+	return strings.Join(fields, " ")
+}
+
+func (p *CronParser) hasSLA() bool {
+	return p.slaStartDelay > 0 || p.slaCompletion > 0
+}
+
 var defaultRules = []parseRule{
 	{Seconds, 0, 59, parseField},
 	{Minutes, 0, 59, parseField},
@@ -99,11 +228,21 @@ var defaultRules = []parseRule{
 	{DayOfMonth, 1, 31, parseField},
 	{Months, 1, 12, parseField},
 	{DayOfWeek, 0, 6, parseField},
-	{Years, 1970, 2099, parseField},
+	{Years, 1970, 9999, parseField},
 }
 
 func newCronParser(expr string, opts ...Option) (*CronParser, error) {
-	if strings.HasPrefix(expr, "@") {
+	original := expr
+	zoneName, expr, zoned := splitZonePrefix(expr)
+	var zone *time.Location
+	if zoned {
+		var err error
+		if zone, err = loadZone(zoneName); err != nil {
+			return nil, err
+		}
+	}
+	macro := strings.HasPrefix(expr, "@")
+	if macro {
 		switch expr {
 		case "@yearly", "@annually":
 			expr = Yearly
@@ -117,17 +256,32 @@ func newCronParser(expr string, opts ...Option) (*CronParser, error) {
 			expr = Hourly
 		case "@minutely":
 			expr = Minutely
+		default:
+			return nil, fmt.Errorf("unknown macro %s", expr)
 		}
 	}
 
 	parser := &CronParser{
+		expr:     original,
 		location: time.Local,
 	}
 	for _, opt := range opts {
 		opt(parser)
 	}
+	// A zone in the expression takes precedence over WithLocation.
+	parser.optionLocation = parser.location
+	if zoned {
+		parser.location = zone
+	}
 
 	parts := strings.Fields(expr)
+	dialect := parser.dialect
+	if macro && dialect == DialectQuartz {
+		dialect = DialectAuto // macros expand to standard fields
+	}
+	if err := parser.applyDialect(dialect, parts); err != nil {
+		return nil, err
+	}
 	rules := make([]parseRule, 0, len(defaultRules))
 	for _, rule := range defaultRules {
 		if rule.field == Seconds && !parser.enableSeconds ||
@@ -138,20 +292,34 @@ func newCronParser(expr string, opts ...Option) (*CronParser, error) {
 	}
 
 	if len(parts) != len(rules) {
-		return nil, fmt.Errorf("invalid cron expression length: expected %d fields, got %d", len(rules), len(parts))
+		names := make([]string, len(rules))
+		for i, rule := range rules {
+			names[i] = rule.field.String()
+		}
+		return nil, fmt.Errorf("invalid cron expression length: expected %d fields (%s), got %d",
+			len(rules), strings.Join(names, " "), len(parts))
 	}
 
-	parsed := make(map[FieldType]map[int]struct{}, len(parts))
+	parser.fields = make(map[FieldType]string, len(parts))
 	for i, part := range parts {
 		rule := rules[i]
+		part, err := resolveHash(part, rule, parser.hashSeed)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s field %q (allowed %d-%d): %v", rule.field, parts[i], rule.min, rule.max, err)
+		}
+		parser.fields[rule.field] = part
 		vals, err := rule.parseFunc(part, rule.min, rule.max, rule.field)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing field %d (%s): %v", i, part, err)
+			return nil, fmt.Errorf("error parsing %s field %q (allowed %d-%d): %v", rule.field, part, rule.min, rule.max, err)
 		}
 		if len(vals) == 0 {
-			return nil, fmt.Errorf("invalid field %d (%s)", i, part)
+			return nil, fmt.Errorf("invalid %s field %q", rule.field, part)
 		}
-		parsed[rule.field] = vals
+		if _, ok := vals[7]; ok && rule.field == DayOfWeek {
+			delete(vals, 7) // 7 is Sunday
+			vals[0] = struct{}{}
+		}
+		parser.compile(rule.field, vals)
 
 		// Track wildcards for dayOfMonth/dayOfWeek OR logic
 		isWildcard := (part == "*" || part == "?")
@@ -163,25 +331,55 @@ func newCronParser(expr string, opts ...Option) (*CronParser, error) {
 		}
 	}
 
-	fieldMap := map[FieldType]func(map[int]struct{}){
-		Seconds:    func(vals map[int]struct{}) { parser.seconds = vals },
-		Minutes:    func(vals map[int]struct{}) { parser.minutes = vals },
-		Hours:      func(vals map[int]struct{}) { parser.hours = vals },
-		DayOfMonth: func(vals map[int]struct{}) { parser.dayOfMonth = vals },
-		Months:     func(vals map[int]struct{}) { parser.months = vals },
-		DayOfWeek:  func(vals map[int]struct{}) { parser.dayOfWeek = vals },
-		Years:      func(vals map[int]struct{}) { parser.years = vals },
-	}
-
-	for f, v := range parsed {
-		fieldMap[f](v)
-	}
-
 	parser.normalization()
 
 	return parser, nil
 }
 
+// compile stores the values parsed for a field, splitting the day tokens encoded
+// by parseField into their own sets.
+func (p *CronParser) compile(field FieldType, vals map[int]struct{}) {
+	switch field {
+	case Seconds:
+		p.seconds = newBitset(vals)
+	case Minutes:
+		p.minutes = newBitset(vals)
+	case Hours:
+		p.hours = newBitset(vals)
+	case Months:
+		p.months = newBitset(vals)
+	case Years:
+		p.years = sortedKeys(vals)
+	case DayOfMonth:
+		for d := range vals {
+			switch {
+			case d == 0:
+				p.lastDays = p.lastDays.add(0)
+			case d == domLastWeekday:
+				p.lastWeekday = true
+			case d >= domLastOffset:
+				p.lastDays = p.lastDays.add(d - domLastOffset)
+			case d < 0:
+				p.nearestWeekdays = p.nearestWeekdays.add(-d)
+			default:
+				p.dayOfMonth = p.dayOfMonth.add(d)
+			}
+		}
+	case DayOfWeek:
+		for w := range vals {
+			switch {
+			case w >= dowNth:
+				nth, weekday := (w-dowNth)/10, (w-dowNth)%10
+				p.nthWeekdays = p.nthWeekdays.add(7*(nth-1) + weekday)
+			case w < 0:
+				p.lastWeekdays = p.lastWeekdays.add(-w % 7)
+			default:
+				p.dayOfWeek = p.dayOfWeek.add(w)
+			}
+		}
+	}
+}
+
 func parseField(field string, min, max int, fieldType FieldType) (map[int]struct{}, error) {
 	if field == "*" || field == "?" {
 		result := make(map[int]struct{}, max-min+1)
@@ -206,6 +404,33 @@ func parseField(field string, min, max int, fieldType FieldType) (map[int]struct
 		return result, nil
 	}
 
+	// L-n contains "-" but is not a range.
+	if offset, ok := strings.CutPrefix(field, "L-"); ok {
+		if fieldType != DayOfMonth {
+			return nil, fmt.Errorf("expression L-n only allowed in DayOfMonth field: %s", field)
+		}
+		n, err := strconv.Atoi(offset)
+		if err != nil || n < 0 || n > max-1 {
+			return nil, fmt.Errorf("invalid 'L-n' offset: %s", offset)
+		}
+		return map[int]struct{}{domLastOffset + n: {}}, nil
+	}
+
+	if weekday, nth, ok := strings.Cut(field, "#"); ok {
+		if fieldType != DayOfWeek {
+			return nil, fmt.Errorf("expression # only allowed in DayOfWeek field: %s", field)
+		}
+		w, err := parseValue(weekday, min, max, fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid '#' weekday: %s", weekday)
+		}
+		n, err := strconv.Atoi(nth)
+		if err != nil || n < 1 || n > 5 {
+			return nil, fmt.Errorf("invalid '#' occurrence: %s", nth)
+		}
+		return map[int]struct{}{dowNth + 10*n + w%7: {}}, nil
+	}
+
 	// Check "/" before "-" because "10-30/5" contains both but should be handled as step
 	if strings.Contains(field, "/") {
 		parts := strings.Split(field, "/")
@@ -234,21 +459,18 @@ func parseField(field string, min, max int, fieldType FieldType) (map[int]struct
 			if len(rangeParts) != 2 {
 				return nil, fmt.Errorf("invalid range format in step expression: %s", field)
 			}
-			start, err = strconv.Atoi(rangeParts[0])
-			if err != nil || start < min || start > max {
+			start, err = parseValue(rangeParts[0], min, max, fieldType)
+			if err != nil {
 				return nil, fmt.Errorf("invalid range start in step expression: %s", rangeParts[0])
 			}
-			end, err = strconv.Atoi(rangeParts[1])
-			if err != nil || end < min || end > max {
+			end, err = parseValue(rangeParts[1], min, max, fieldType)
+			if err != nil {
 				return nil, fmt.Errorf("invalid range end in step expression: %s", rangeParts[1])
 			}
-			if start > end {
-				return nil, fmt.Errorf("range start cannot be greater than end: %s", field)
-			}
 		} else {
 			// number/step: start from the number
-			start, err = strconv.Atoi(base)
-			if err != nil || start < min || start > max {
+			start, err = parseValue(base, min, max, fieldType)
+			if err != nil {
 				return nil, fmt.Errorf("invalid start value in step expression: %s", base)
 			}
 			end = max
@@ -256,12 +478,7 @@ func parseField(field string, min, max int, fieldType FieldType) (map[int]struct
 
 		// Generate values from start to end with given step
 		// No need to check if (end-start+1) % step == 0, standard cron allows any step
-		result := make(map[int]struct{})
-		for i := start; i <= end; i += step {
-			result[i] = struct{}{}
-		}
-
-		return result, nil
+		return expandRange(field, start, end, step, min, max, fieldType)
 	}
 
 	// Pure range without step (e.g., "10-30")
@@ -271,26 +488,29 @@ func parseField(field string, min, max int, fieldType FieldType) (map[int]struct
 			return nil, fmt.Errorf("invalid range format: %s", field)
 		}
 
-		start, err := strconv.Atoi(parts[0])
-		if err != nil || start < min || start > max {
+		start, err := parseValue(parts[0], min, max, fieldType)
+		if err != nil {
 			return nil, fmt.Errorf("invalid range start: %s", parts[0])
 		}
 
-		end, err := strconv.Atoi(parts[1])
-		if err != nil || end < min || end > max {
+		end, err := parseValue(parts[1], min, max, fieldType)
+		if err != nil {
 			return nil, fmt.Errorf("invalid range end: %s", parts[1])
 		}
 
-		if start > end {
-			return nil, fmt.Errorf("range start cannot be greater than end: %s", field)
-		}
+		return expandRange(field, start, end, 1, min, max, fieldType)
+	}
 
-		result := make(map[int]struct{}, end-start+1)
-		for i := start; i <= end; i++ {
-			result[i] = struct{}{}
-		}
+	// Names are checked before L and W, which they may contain (JUL, WED).
+	if v, ok := fieldNames(fieldType)[strings.ToUpper(field)]; ok {
+		return map[int]struct{}{v: {}}, nil
+	}
 
-		return result, nil
+	if field == "LW" {
+		if fieldType != DayOfMonth {
+			return nil, fmt.Errorf("expression LW only allowed in DayOfMonth field: %s", field)
+		}
+		return map[int]struct{}{domLastWeekday: {}}, nil
 	}
 
 	if strings.Contains(field, "L") {
@@ -299,8 +519,8 @@ func parseField(field string, min, max int, fieldType FieldType) (map[int]struct
 		}
 		if len(field) > 1 {
 			numStr := field[:len(field)-1]
-			num, err := strconv.Atoi(numStr)
-			if err != nil || num < min || num > max {
+			num, err := parseValue(numStr, min, max, fieldType)
+			if err != nil {
 				return nil, fmt.Errorf("invalid 'L' number: %s", numStr)
 			}
 			// For DayOfWeek, 'L' means the last occurrence of the day in the month
@@ -309,7 +529,11 @@ func parseField(field string, min, max int, fieldType FieldType) (map[int]struct
 			// This requires special handling in the scheduling logic
 			// Here we just return the negative value to indicate this
 			// The actual calculation will be done in the Next function
+			// Sunday is stored as 7 here, since -0 could not be told apart from it.
 			if fieldType == DayOfWeek {
+				if num == 0 {
+					num = 7
+				}
 				return map[int]struct{}{-num: {}}, nil
 			}
 		}
@@ -337,24 +561,97 @@ func parseField(field string, min, max int, fieldType FieldType) (map[int]struct
 		return map[int]struct{}{-num: {}}, nil
 	}
 
-	num, err := strconv.Atoi(field)
-	if err != nil || num < min || num > max {
+	num, err := parseValue(field, min, max, fieldType)
+	if err != nil {
 		return nil, fmt.Errorf("invalid number: %s", field)
 	}
 
 	return map[int]struct{}{num: {}}, nil
 }
 
-func (p *CronParser) normalization() {
-	if p.enableSeconds && len(p.seconds) == 0 {
-		p.seconds = map[int]struct{}{0: {}}
+// expandRange returns the values from start to end by step. Where start is greater
+// than end the range wraps around past max, e.g. hours 22-2 are 22, 23, 0, 1 and 2.
+// Day-of-month and year ranges do not wrap.
+func expandRange(field string, start, end, step, min, max int, fieldType FieldType) (map[int]struct{}, error) {
+	if fieldType == DayOfWeek && start == 7 && end < start {
+		start = 0 // 7-2 is Sunday through Tuesday
 	}
-	p.sortedSeconds = sortedKeys(p.seconds)
-	p.sortedMinutes = sortedKeys(p.minutes)
-	p.sortedHours = sortedKeys(p.hours)
-	p.sortedMonths = sortedKeys(p.months)
-	if p.enableYears {
-		p.sortedYears = sortedKeys(p.years)
+	if start <= end {
+		result := make(map[int]struct{}, (end-start)/step+1)
+		for i := start; i <= end; i += step {
+			result[i] = struct{}{}
+		}
+		return result, nil
+	}
+	if fieldType == DayOfMonth || fieldType == Years {
+		return nil, fmt.Errorf("range start cannot be greater than end: %s", field)
+	}
+
+	size := max - min + 1
+	span := end - start + size
+	result := make(map[int]struct{}, span/step+1)
+	for off := 0; off <= span; off += step {
+		result[min+(start-min+off)%size] = struct{}{}
+	}
+	return result, nil
+}
+
+// parseField returns day tokens as values outside the plain ranges, which compile
+// turns into separate sets. Day of month: 0 is L, -n is nW, domLastWeekday is LW and
+// domLastOffset+n is L-n. Day of week: -n is nL (Sunday as -7) and
+// dowNth+10*k+n is n#k, the k-th weekday n of the month.
+const (
+	domLastWeekday = -32
+	domLastOffset  = 100
+	dowNth         = 100
+)
+
+// gregorianCycle is the number of years after which the Gregorian calendar,
+// including weekdays and leap days, repeats.
+const gregorianCycle = 400
+
+var (
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	weekdayNames = map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}
+)
+
+// fieldNames returns the names accepted in place of numbers in a field, if any.
+func fieldNames(fieldType FieldType) map[string]int {
+	switch fieldType {
+	case Months:
+		return monthNames
+	case DayOfWeek:
+		return weekdayNames
+	default:
+		return nil
+	}
+}
+
+// parseValue parses a single value: a number within [min, max] or, for months and
+// weekdays, a case-insensitive three-letter name. 7 is accepted as Sunday and is
+// returned as 7 so that ranges such as 5-7 can be expanded; callers fold it to 0.
+func parseValue(tok string, min, max int, fieldType FieldType) (int, error) {
+	if v, ok := fieldNames(fieldType)[strings.ToUpper(tok)]; ok {
+		return v, nil
+	}
+	if fieldType == DayOfWeek && max == 6 {
+		max = 7
+	}
+	num, err := strconv.Atoi(tok)
+	if err != nil || num < min || num > max {
+		return 0, fmt.Errorf("invalid value: %s", tok)
+	}
+	return num, nil
+}
+
+func (p *CronParser) normalization() {
+	if p.enableSeconds && p.seconds == 0 {
+		p.seconds = p.seconds.add(0)
 	}
 }
 
@@ -367,60 +664,86 @@ func sortedKeys(m map[int]struct{}) []int {
 	return keys
 }
 
-// Next returns the next time after t that matches the cron expression.
+// Next returns the next time after t that matches the cron expression, or the zero
+// time if the expression never fires again: its year field is exhausted, or it has
+// no fire time within a full 400-year calendar cycle, such as "0 0 30 2 *".
+// Wall times skipped or repeated by DST transitions are resolved by WithDSTPolicy.
 // Uses field-jumping: O(F × V) where F=fields, V=max values per field.
 func (p *CronParser) Next(t time.Time) time.Time {
 	t = t.In(p.location)
-	if p.enableSeconds {
-		t = t.Add(time.Second).Truncate(time.Second)
-	} else {
-		t = t.Add(time.Minute).Truncate(time.Minute)
+	unit := p.unit()
+	// Step the wall clock rather than the instant, which would jump over the wall
+	// times of a gap that starts within the unit.
+	_, offset := t.Zone()
+	wall := t.UTC().Add(time.Duration(offset) * time.Second)
+	next := p.nextFrom(t, wall.Add(unit).Truncate(unit))
+	if from, ok := p.rewindNext(t); ok {
+		if alt := p.nextFrom(t, from.Truncate(unit)); !alt.IsZero() && (next.IsZero() || alt.Before(next)) {
+			next = alt
+		}
 	}
+	return next
+}
 
-	year := t.Year()
-	month := int(t.Month())
-	day := t.Day()
-	hour := t.Hour()
-	minute := t.Minute()
-	second := t.Second()
+// unit returns the resolution of the expression: a second, or a minute without
+// the seconds field.
+func (p *CronParser) unit() time.Duration {
+	if p.enableSeconds {
+		return time.Second
+	}
+	return time.Minute
+}
 
-	maxYear := year + 5
+// nextFrom returns the first fire time after t whose wall clock time is at or after
+// that of from.
+func (p *CronParser) nextFrom(t, from time.Time) time.Time {
+	year := from.Year()
+	month := int(from.Month())
+	day := from.Day()
+	hour := from.Hour()
+	minute := from.Minute()
+	second := from.Second()
+
+	// The Gregorian calendar repeats every 400 years, so a schedule without a year
+	// field that has no fire time within one cycle never fires.
+	maxYear := year + gregorianCycle
+	if p.enableYears {
+		maxYear = p.years[len(p.years)-1]
+	}
 
-	for iteration := 0; iteration < 25; iteration++ {
+	for year <= maxYear {
 		// Year
 		if p.enableYears {
-			y, found := nextInSorted(p.sortedYears, year)
-			if !found || y > maxYear {
+			y, found := nextInSorted(p.years, year)
+			if !found {
 				return time.Time{}
 			}
 			if y != year {
 				year = y
-				month = p.sortedMonths[0]
+				month = p.months.first()
 				day = 1
-				hour = p.sortedHours[0]
-				minute = p.sortedMinutes[0]
+				hour = p.hours.first()
+				minute = p.minutes.first()
 				second = p.firstSecond()
 			}
-		} else if year > maxYear {
-			return time.Time{}
 		}
 
 		// Month
-		m, found := nextInSorted(p.sortedMonths, month)
+		m, found := p.months.next(month)
 		if !found {
 			year++
-			month = p.sortedMonths[0]
+			month = p.months.first()
 			day = 1
-			hour = p.sortedHours[0]
-			minute = p.sortedMinutes[0]
+			hour = p.hours.first()
+			minute = p.minutes.first()
 			second = p.firstSecond()
 			continue
 		}
 		if m != month {
 			month = m
 			day = 1
-			hour = p.sortedHours[0]
-			minute = p.sortedMinutes[0]
+			hour = p.hours.first()
+			minute = p.minutes.first()
 			second = p.firstSecond()
 		}
 
@@ -433,8 +756,8 @@ func (p *CronParser) Next(t time.Time) time.Time {
 				year++
 			}
 			day = 1
-			hour = p.sortedHours[0]
-			minute = p.sortedMinutes[0]
+			hour = p.hours.first()
+			minute = p.minutes.first()
 			second = p.firstSecond()
 			continue
 		}
@@ -446,38 +769,38 @@ func (p *CronParser) Next(t time.Time) time.Time {
 				year++
 			}
 			day = 1
-			hour = p.sortedHours[0]
-			minute = p.sortedMinutes[0]
+			hour = p.hours.first()
+			minute = p.minutes.first()
 			second = p.firstSecond()
 			continue
 		}
 		if d != day {
 			day = d
-			hour = p.sortedHours[0]
-			minute = p.sortedMinutes[0]
+			hour = p.hours.first()
+			minute = p.minutes.first()
 			second = p.firstSecond()
 		}
 
 		// Hour
-		h, found := nextInSorted(p.sortedHours, hour)
+		h, found := p.hours.next(hour)
 		if !found {
 			day++
-			hour = p.sortedHours[0]
-			minute = p.sortedMinutes[0]
+			hour = p.hours.first()
+			minute = p.minutes.first()
 			second = p.firstSecond()
 			continue
 		}
 		if h != hour {
 			hour = h
-			minute = p.sortedMinutes[0]
+			minute = p.minutes.first()
 			second = p.firstSecond()
 		}
 
 		// Minute
-		mi, found := nextInSorted(p.sortedMinutes, minute)
+		mi, found := p.minutes.next(minute)
 		if !found {
 			hour++
-			minute = p.sortedMinutes[0]
+			minute = p.minutes.first()
 			second = p.firstSecond()
 			continue
 		}
@@ -488,36 +811,194 @@ func (p *CronParser) Next(t time.Time) time.Time {
 
 		// Second
 		if p.enableSeconds {
-			s, found := nextInSorted(p.sortedSeconds, second)
+			s, found := p.seconds.next(second)
 			if !found {
 				minute++
-				second = p.sortedSeconds[0]
+				second = p.seconds.first()
 				continue
 			}
 			second = s
 		}
 
-		// Validate date (e.g., Feb 30 overflows to March)
-		result := time.Date(year, time.Month(month), day, hour, minute, second, 0, p.location)
-		if result.Year() == year && result.Month() == time.Month(month) && result.Day() == day {
-			return result
+		// The day is valid for the month; the wall time may still be skipped or
+		// repeated by a DST transition.
+		insts, n := p.instants(year, month, day, hour, minute, second)
+		for _, at := range insts[:n] {
+			if at.After(t) {
+				return at
+			}
 		}
-		// Date overflow: advance to next month
-		month++
-		if month > 12 {
-			month = 1
-			year++
+		if p.enableSeconds {
+			second++
+		} else {
+			minute++
+		}
+	}
+
+	return time.Time{}
+}
+
+// Prev returns the latest time before t that matches the cron expression, or the
+// zero time if there is none within the year field or the preceding 400-year
+// calendar cycle. It mirrors Next, jumping backwards field by field, and resolves
+// DST transitions the same way.
+func (p *CronParser) Prev(t time.Time) time.Time {
+	t = t.In(p.location)
+	unit := p.unit()
+	// Latest whole unit strictly before t.
+	prev := p.prevFrom(t, t.Add(-time.Nanosecond).Truncate(unit))
+	if from, ok := p.rewindPrev(t); ok {
+		if alt := p.prevFrom(t, from.Truncate(unit)); alt.After(prev) {
+			prev = alt
+		}
+	}
+	return prev
+}
+
+// prevFrom returns the last fire time before t whose wall clock time is at or
+// before that of from.
+func (p *CronParser) prevFrom(t, from time.Time) time.Time {
+	year := from.Year()
+	month := int(from.Month())
+	day := from.Day()
+	hour := from.Hour()
+	minute := from.Minute()
+	second := from.Second()
+
+	minYear := year - gregorianCycle
+	if p.enableYears {
+		minYear = p.years[0]
+	}
+
+	for year >= minYear {
+		// Year
+		if p.enableYears {
+			y, found := prevInSorted(p.years, year)
+			if !found {
+				return time.Time{}
+			}
+			if y != year {
+				year = y
+				month = p.months.last()
+				day = daysInMonth(year, time.Month(month))
+				hour, minute, second = p.lastHour(), p.lastMinute(), p.lastSecond()
+			}
+		}
+
+		// Month
+		m, found := p.months.prev(month)
+		if !found {
+			year--
+			month = p.months.last()
+			day = daysInMonth(year, time.Month(month))
+			hour, minute, second = p.lastHour(), p.lastMinute(), p.lastSecond()
+			continue
+		}
+		if m != month {
+			month = m
+			day = daysInMonth(year, time.Month(month))
+			hour, minute, second = p.lastHour(), p.lastMinute(), p.lastSecond()
+		}
+
+		// Day (handles L/W and OR logic)
+		if day < 1 {
+			year, month = prevMonth(year, month)
+			day = daysInMonth(year, time.Month(month))
+			hour, minute, second = p.lastHour(), p.lastMinute(), p.lastSecond()
+			continue
+		}
+		d, dayFound := p.prevValidDay(year, time.Month(month), day)
+		if !dayFound {
+			year, month = prevMonth(year, month)
+			day = daysInMonth(year, time.Month(month))
+			hour, minute, second = p.lastHour(), p.lastMinute(), p.lastSecond()
+			continue
+		}
+		if d != day {
+			day = d
+			hour, minute, second = p.lastHour(), p.lastMinute(), p.lastSecond()
+		}
+
+		// Hour
+		h, found := p.hours.prev(hour)
+		if !found {
+			day--
+			hour, minute, second = p.lastHour(), p.lastMinute(), p.lastSecond()
+			continue
+		}
+		if h != hour {
+			hour = h
+			minute, second = p.lastMinute(), p.lastSecond()
+		}
+
+		// Minute
+		mi, found := p.minutes.prev(minute)
+		if !found {
+			hour--
+			minute, second = p.lastMinute(), p.lastSecond()
+			continue
+		}
+		if mi != minute {
+			minute = mi
+			second = p.lastSecond()
+		}
+
+		// Second
+		if p.enableSeconds {
+			s, found := p.seconds.prev(second)
+			if !found {
+				minute--
+				second = p.lastSecond()
+				continue
+			}
+			second = s
+		}
+
+		insts, n := p.instants(year, month, day, hour, minute, second)
+		for i := n - 1; i >= 0; i-- {
+			if insts[i].Before(t) {
+				return insts[i]
+			}
+		}
+		if p.enableSeconds {
+			second--
+		} else {
+			minute--
 		}
-		day = 1
-		hour = p.sortedHours[0]
-		minute = p.sortedMinutes[0]
-		second = p.firstSecond()
 	}
 
 	return time.Time{}
 }
 
-// nextInSorted finds the smallest value >= val in a sorted slice.
+// Matches reports whether t is a fire time, ignoring precision below the
+// expression's resolution (a second, or a minute without the seconds field).
+func (p *CronParser) Matches(t time.Time) bool {
+	t = t.In(p.location)
+	if !p.matchesWall(t) {
+		return p.gapFires(t)
+	}
+	return p.overlapFires(t)
+}
+
+// matchesWall reports whether the wall clock fields of t match the expression.
+func (p *CronParser) matchesWall(t time.Time) bool {
+	year, month, day := t.Date()
+	if p.enableYears {
+		if _, found := slices.BinarySearch(p.years, year); !found {
+			return false
+		}
+	}
+	if !p.months.has(int(month)) || !p.isDayValid(year, month, day) {
+		return false
+	}
+	hour, minute, second := t.Clock()
+	if !p.hours.has(hour) || !p.minutes.has(minute) {
+		return false
+	}
+	return !p.enableSeconds || p.seconds.has(second)
+}
+
+// nextInSorted finds the smallest value >= val in a sorted slice, such as the years.
 func nextInSorted(sorted []int, val int) (int, bool) {
 	idx := sort.SearchInts(sorted, val)
 	if idx < len(sorted) {
@@ -526,14 +1007,53 @@ func nextInSorted(sorted []int, val int) (int, bool) {
 	return 0, false
 }
 
+// prevInSorted finds the largest value <= val in a sorted slice.
+func prevInSorted(sorted []int, val int) (int, bool) {
+	idx := sort.SearchInts(sorted, val+1)
+	if idx > 0 {
+		return sorted[idx-1], true
+	}
+	return 0, false
+}
+
+// prevMonth returns the month before year/month.
+func prevMonth(year, month int) (int, int) {
+	if month == 1 {
+		return year - 1, 12
+	}
+	return year, month - 1
+}
+
+func (p *CronParser) lastHour() int {
+	return p.hours.last()
+}
+
+func (p *CronParser) lastMinute() int {
+	return p.minutes.last()
+}
+
+// lastSecond returns the last valid second, or 0 if seconds not enabled.
+func (p *CronParser) lastSecond() int {
+	if p.enableSeconds {
+		return p.seconds.last()
+	}
+	return 0
+}
+
 // firstSecond returns the first valid second, or 0 if seconds not enabled.
 func (p *CronParser) firstSecond() int {
-	if p.enableSeconds && len(p.sortedSeconds) > 0 {
-		return p.sortedSeconds[0]
+	if p.enableSeconds {
+		return p.seconds.first()
 	}
 	return 0
 }
 
+// weekdayOf returns the day of the week of a date. It is computed in UTC because
+// midnight may not exist in the schedule's location on days clocks change.
+func weekdayOf(year int, month time.Month, day int) time.Weekday {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+}
+
 // daysInMonth returns the number of days in the given month/year.
 func daysInMonth(year int, month time.Month) int {
 	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
@@ -550,6 +1070,16 @@ func (p *CronParser) nextValidDay(year int, month time.Month, startDay int) (int
 	return 0, false
 }
 
+// prevValidDay finds the previous valid day <= startDay in the given year/month.
+func (p *CronParser) prevValidDay(year int, month time.Month, startDay int) (int, bool) {
+	for day := min(startDay, daysInMonth(year, month)); day >= 1; day-- {
+		if p.isDayValid(year, month, day) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
 // isDayValid checks dayOfMonth/dayOfWeek constraints with OR logic.
 func (p *CronParser) isDayValid(year int, month time.Month, day int) bool {
 	if p.dayOfMonthWildcard && p.dayOfWeekWildcard {
@@ -569,55 +1099,50 @@ func (p *CronParser) isDayValid(year int, month time.Month, day int) bool {
 	return domValid || dowValid
 }
 
-// isDayOfMonthMatch handles L (last day) and W (nearest weekday).
+// isDayOfMonthMatch handles L (last day), L-n, LW and W (nearest weekday).
 func (p *CronParser) isDayOfMonthMatch(year int, month time.Month, day int) bool {
-	for d := range p.dayOfMonth {
-		switch {
-		case d == 0: // L: last day of month
-			if day == daysInMonth(year, month) {
-				return true
-			}
-		case d < 0: // W: nearest weekday to day -d
-			if day == findNearestWeekday(year, month, -d, p.location) {
-				return true
-			}
-		default:
-			if day == d {
-				return true
-			}
+	if p.dayOfMonth.has(day) {
+		return true
+	}
+	if p.lastDays.has(daysInMonth(year, month) - day) {
+		return true
+	}
+	if p.lastWeekday && day == findLastBusinessDay(year, month) {
+		return true
+	}
+	// The nearest weekday to day n is at most two days away from it.
+	for n := max(day-2, 1); p.nearestWeekdays != 0 && n <= day+2; n++ {
+		if p.nearestWeekdays.has(n) && day == findNearestWeekday(year, month, n) {
+			return true
 		}
 	}
 	return false
 }
 
-// isDayOfWeekMatch handles nL (last nth weekday of month).
+// isDayOfWeekMatch handles nL (last weekday n of the month) and n#k.
 func (p *CronParser) isDayOfWeekMatch(year int, month time.Month, day int) bool {
-	weekday := int(time.Date(year, month, day, 0, 0, 0, 0, p.location).Weekday())
-	for w := range p.dayOfWeek {
-		if w < 0 {
-			targetWeekday := -w
-			lastDay := findLastWeekdayOfMonth(year, month, targetWeekday, p.location)
-			if day == lastDay {
-				return true
-			}
-		} else if weekday == w {
-			return true
-		}
+	weekday := int(weekdayOf(year, month, day))
+	if p.dayOfWeek.has(weekday) {
+		return true
 	}
-	return false
+	// Day is in the ((day-1)/7+1)-th week of the month, and is the last of its
+	// weekday when fewer than seven days remain.
+	if p.nthWeekdays.has(7*((day-1)/7) + weekday) {
+		return true
+	}
+	return p.lastWeekdays.has(weekday) && day+7 > daysInMonth(year, month)
 }
 
 // findNearestWeekday returns the nearest weekday (Mon-Fri) to targetDay.
 // Saturday -> previous Friday (or next Monday if at month start).
 // Sunday -> next Monday (or previous Friday if at month end).
-func findNearestWeekday(year int, month time.Month, targetDay int, loc *time.Location) int {
-	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+func findNearestWeekday(year int, month time.Month, targetDay int) int {
+	lastDay := daysInMonth(year, month)
 	if targetDay < 1 || targetDay > lastDay {
 		return -1
 	}
 
-	t := time.Date(year, month, targetDay, 0, 0, 0, 0, loc)
-	wd := t.Weekday()
+	wd := weekdayOf(year, month, targetDay)
 
 	if wd >= time.Monday && wd <= time.Friday {
 		return targetDay
@@ -640,14 +1165,15 @@ func findNearestWeekday(year int, month time.Month, targetDay int, loc *time.Loc
 	return targetDay
 }
 
-// findLastWeekdayOfMonth returns the last occurrence of targetWeekday (0=Sun, 6=Sat).
-func findLastWeekdayOfMonth(year int, month time.Month, targetWeekday int, loc *time.Location) int {
-	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
-	for day := lastDay; day >= 1; day-- {
-		t := time.Date(year, month, day, 0, 0, 0, 0, loc)
-		if int(t.Weekday()) == targetWeekday {
-			return day
-		}
+// findLastBusinessDay returns the last weekday (Mon-Fri) of the month.
+func findLastBusinessDay(year int, month time.Month) int {
+	lastDay := daysInMonth(year, month)
+	switch weekdayOf(year, month, lastDay) {
+	case time.Saturday:
+		return lastDay - 1
+	case time.Sunday:
+		return lastDay - 2
+	default:
+		return lastDay
 	}
-	return -1
 }
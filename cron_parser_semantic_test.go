@@ -1,6 +1,7 @@
 package golitecron
 
 import (
+	"math/rand/v2"
 	"testing"
 	"time"
 )
@@ -255,7 +256,7 @@ func TestCron_CommaList(t *testing.T) {
 		t.Fatalf("failed to create parser: %v", err)
 	}
 
-	if !mapHas(parser.minutes, 0, 30) {
+	if !setHas(parser.minutes, 0, 30) {
 		t.Errorf("expected minutes to contain 0 and 30, got %v", parser.minutes)
 	}
 }
@@ -269,7 +270,7 @@ func TestCron_RangeExpression(t *testing.T) {
 	}
 
 	expected := []int{9, 10, 11, 12, 13, 14, 15, 16, 17}
-	if !mapHas(parser.hours, expected...) {
+	if !setHas(parser.hours, expected...) {
 		t.Errorf("expected hours %v, got %v", expected, parser.hours)
 	}
 }
@@ -295,11 +296,10 @@ func TestCron_QuestionMark(t *testing.T) {
 func TestCron_Normalization(t *testing.T) {
 	parser := &CronParser{
 		enableSeconds: true,
-		seconds:       nil,
 	}
 	parser.normalization()
 
-	if !mapHas(parser.seconds, 0) {
+	if !setHas(parser.seconds, 0) {
 		t.Error("expected seconds to be normalized to {0}")
 	}
 }
@@ -324,4 +324,243 @@ func TestCron_LocationAware(t *testing.T) {
 	if next.Hour() != 9 {
 		t.Errorf("expected hour 9 in Tokyo timezone, got %d", next.Hour())
 	}
-}
\ No newline at end of file
+}
+
+// TestCron_Prev tests previous fire times, including special day tokens
+func TestCron_Prev(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		opts []Option
+		from time.Time
+		want time.Time
+	}{
+		{"same day", "30 9 * * *", nil, time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)},
+		{"strictly before", "30 9 * * *", nil, time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC), time.Date(2024, 3, 9, 9, 30, 0, 0, time.UTC)},
+		{"sub-minute", "30 9 * * *", nil, time.Date(2024, 3, 10, 9, 30, 0, 1, time.UTC), time.Date(2024, 3, 10, 9, 30, 0, 0, time.UTC)},
+		{"year boundary", "0 0 1 1 *", nil, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"last day", "0 0 L * *", nil, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"nearest weekday", "0 0 15W * *", nil, time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 14, 0, 0, 0, 0, time.UTC)}, // 15th is a Saturday
+		{"last friday", "0 0 * * 5L", nil, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 23, 0, 0, 0, 0, time.UTC)},
+		{"dom or dow", "0 0 15 * 1", nil, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", nil, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"seconds", "*/15 * * * * *", []Option{WithSeconds()}, time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC), time.Date(2024, 3, 10, 8, 59, 45, 0, time.UTC)},
+		{"years", "0 0 1 6 * 2022-2023", []Option{WithYears()}, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"years exhausted", "0 0 1 6 * 2030", []Option{WithYears()}, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := newCronParser(tt.expr, append([]Option{WithLocation(time.UTC)}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("failed to create parser: %v", err)
+			}
+			if got := parser.Prev(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Prev(%v) = %v, want %v", tt.from, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCron_PrevDST tests that Prev never returns a time at or after t across a spring-forward gap
+func TestCron_PrevDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("New York timezone not available: %v", err)
+	}
+	parser, err := newCronParser("30 2 * * *", WithLocation(ny))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	// 2024-03-10 02:30 does not exist in New York.
+	from := time.Date(2024, 3, 10, 3, 10, 0, 0, ny)
+	want := time.Date(2024, 3, 9, 2, 30, 0, 0, ny)
+	if got := parser.Prev(from); !got.Equal(want) {
+		t.Errorf("Prev(%v) = %v, want %v", from, got, want)
+	}
+}
+
+// TestCron_PrevNextRoundTrip checks that Prev(Next(t)) is the last fire time at or
+// before t, across a range of expressions, time zones and start times
+func TestCron_PrevNextRoundTrip(t *testing.T) {
+	exprs := []struct {
+		expr string
+		opts []Option
+	}{
+		{"*/7 * * * *", nil},
+		{"30 9 * * 1-5", nil},
+		{"0 0 L * *", nil},
+		{"0 12 15W * *", nil},
+		{"0 18 * * 5L", nil},
+		{"0 0 13 * 5", nil},
+		{"0 6 * * 1#1,5#3", nil},
+		{"0 0 LW * *", nil},
+		{"0 0 L-2 * *", nil},
+		{"0 0 29 2 *", nil},
+		{"0 30 2 * * *", []Option{WithSeconds()}},
+		{"*/20 */5 * * * *", []Option{WithSeconds()}},
+		{"0 0 1 1,7 * 2025-2028", []Option{WithYears()}},
+	}
+	// Zones with and without DST, including Lord Howe's half-hour shift.
+	zones := []string{"UTC", "Asia/Kolkata", "Asia/Kathmandu", "America/New_York", "Australia/Lord_Howe"}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, zone := range zones {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			t.Skipf("timezone %s not available: %v", zone, err)
+		}
+		// Start times near each clock change, where Prev is most fragile, in addition
+		// to random ones.
+		var transitions []time.Time
+		for at := base.In(loc); at.Year() < base.Year()+3; {
+			_, end := at.ZoneBounds()
+			if end.IsZero() {
+				break
+			}
+			transitions = append(transitions, end)
+			at = end
+		}
+		for _, e := range exprs {
+			parser, err := newCronParser(e.expr, append([]Option{WithLocation(loc)}, e.opts...)...)
+			if err != nil {
+				t.Fatalf("failed to create parser for %q: %v", e.expr, err)
+			}
+			for i := 0; i < 200+20*len(transitions); i++ {
+				from := base.Add(time.Duration(rng.Int64N(int64(3 * 365 * 24 * time.Hour))))
+				if i >= 200 {
+					from = transitions[(i-200)/20].Add(time.Duration(rng.Int64N(int64(6*time.Hour))) - 3*time.Hour)
+				}
+				next := parser.Next(from)
+				if next.IsZero() {
+					continue
+				}
+				prev := parser.Prev(next)
+				if prev.IsZero() {
+					t.Fatalf("%q in %s: Prev(Next(%v)) = zero, next %v", e.expr, zone, from, next)
+				}
+				if prev.After(from) || !prev.Before(next) {
+					t.Fatalf("%q in %s: Prev(Next(%v)) = %v, next %v", e.expr, zone, from, prev, next)
+				}
+				if again := parser.Next(prev); !again.Equal(next) {
+					t.Fatalf("%q in %s: Next(Prev(%v)) = %v, want %v", e.expr, zone, next, again, next)
+				}
+			}
+		}
+	}
+}
+
+// TestCron_QuartzDayTokens tests n#k, LW and L-n across month lengths and leap years
+func TestCron_QuartzDayTokens(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want []time.Time
+	}{
+		{"third friday", "0 0 * * 5#3", date(2024, 1, 1),
+			[]time.Time{date(2024, 1, 19), date(2024, 2, 16), date(2024, 3, 15)}},
+		{"named nth weekday", "0 0 * * FRI#3", date(2024, 1, 1),
+			[]time.Time{date(2024, 1, 19), date(2024, 2, 16), date(2024, 3, 15)}},
+		{"nth weekday list", "0 0 * * 1#1,5#3", date(2024, 1, 1),
+			[]time.Time{date(2024, 1, 19), date(2024, 2, 5), date(2024, 2, 16)}},
+		// Feb 29, 2024 is the only fifth Thursday of a February in the decade.
+		{"fifth thursday leap year", "0 0 * * 4#5", date(2024, 1, 1),
+			[]time.Time{date(2024, 2, 29), date(2024, 5, 30), date(2024, 8, 29)}},
+		// The next February with five Thursdays is in 2052, beyond the lookahead.
+		{"fifth thursday in february", "0 0 * 2 4#5", date(2025, 1, 1),
+			[]time.Time{date(2052, 2, 29), date(2080, 2, 29)}},
+		{"first sunday as 7", "0 0 * * 7#1", date(2024, 3, 1),
+			[]time.Time{date(2024, 3, 3), date(2024, 4, 7)}},
+		{"last weekday", "0 0 LW * *", date(2024, 1, 1),
+			[]time.Time{date(2024, 1, 31), date(2024, 2, 29), date(2024, 3, 29), date(2024, 4, 30)}},
+		{"last weekday saturday end", "0 0 LW 8 *", date(2024, 1, 1),
+			[]time.Time{date(2024, 8, 30), date(2025, 8, 29)}},
+		{"last weekday common february", "0 0 LW 2 *", date(2023, 1, 1),
+			[]time.Time{date(2023, 2, 28), date(2024, 2, 29), date(2025, 2, 28)}},
+		{"last day offset", "0 0 L-3 * *", date(2024, 1, 1),
+			[]time.Time{date(2024, 1, 28), date(2024, 2, 26), date(2024, 3, 28), date(2024, 4, 27)}},
+		{"last day offset common february", "0 0 L-3 2 *", date(2023, 1, 1),
+			[]time.Time{date(2023, 2, 25), date(2024, 2, 26)}},
+		{"last day offset zero", "0 0 L-0 2 *", date(2023, 1, 1),
+			[]time.Time{date(2023, 2, 28), date(2024, 2, 29)}},
+		{"offset beyond short months", "0 0 L-29 * *", date(2024, 1, 1),
+			[]time.Time{date(2024, 1, 2), date(2024, 3, 2), date(2024, 4, 1)}},
+		{"offset in list", "0 0 1,L-1 * *", date(2024, 2, 1),
+			[]time.Time{date(2024, 2, 28), date(2024, 3, 1), date(2024, 3, 30)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := newCronParser(tt.expr, WithLocation(time.UTC))
+			if err != nil {
+				t.Fatalf("failed to create parser: %v", err)
+			}
+			if len(tt.want) == 0 {
+				if next := parser.Next(tt.from); !next.IsZero() {
+					t.Errorf("expected no fire time, got %v", next)
+				}
+			}
+			next := tt.from
+			for i, want := range tt.want {
+				next = parser.Next(next)
+				if !next.Equal(want) {
+					t.Fatalf("fire %d: got %v, want %v", i, next, want)
+				}
+				if prev := parser.Prev(next.Add(time.Minute)); !prev.Equal(want) {
+					t.Errorf("Prev after fire %d: got %v, want %v", i, prev, want)
+				}
+			}
+		})
+	}
+
+	for _, expr := range []string{"0 0 * * 5#0", "0 0 * * 5#6", "0 0 * * 8#1", "0 0 5#3 * *", "0 0 * * L-3", "0 0 L-31 * *", "0 0 * * LW"} {
+		if _, err := newCronParser(expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}
+
+// TestCron_FarFuture tests sparse expressions beyond a few years and ones that never fire
+func TestCron_FarFuture(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		expr string
+		opts []Option
+		want time.Time // zero: never fires
+	}{
+		{"feb 29 on a monday", "0 0 * 2 1#5", nil, time.Date(2044, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"distant year", "0 0 1 1 * 2500", []Option{WithYears()}, time.Date(2500, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"last supported year", "59 23 31 12 * 9999", []Option{WithYears()}, time.Date(9999, 12, 31, 23, 59, 0, 0, time.UTC)},
+		{"feb 30", "0 0 30 2 *", nil, time.Time{}},
+		{"years exhausted", "0 0 * * * 2020-2025", []Option{WithYears()}, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := newCronParser(tt.expr, append(tt.opts, WithLocation(time.UTC))...)
+			if err != nil {
+				t.Fatalf("failed to create parser: %v", err)
+			}
+			next := parser.Next(from)
+			if !next.Equal(tt.want) {
+				t.Fatalf("got %v, want %v", next, tt.want)
+			}
+			if !next.IsZero() {
+				if prev := parser.Prev(next.Add(time.Minute)); !prev.Equal(next) {
+					t.Errorf("Prev: got %v, want %v", prev, next)
+				}
+			}
+		})
+	}
+
+	if parser, _ := newCronParser("0 0 30 2 *"); !parser.Prev(from).IsZero() {
+		t.Error("expected no previous fire time for Feb 30")
+	}
+	if _, err := newCronParser("0 0 1 1 * 10000", WithYears()); err == nil {
+		t.Error("expected an error for year 10000")
+	}
+}
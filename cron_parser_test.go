@@ -1,14 +1,16 @@
 package golitecron
 
 import (
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
 
 // helpers
-func mapHas(m map[int]struct{}, vals ...int) bool {
+func setHas(set bitset, vals ...int) bool {
 	for _, v := range vals {
-		if _, ok := m[v]; !ok {
+		if !set.has(v) {
 			return false
 		}
 	}
@@ -30,25 +32,25 @@ func TestNewCronParser_ParseFields(t *testing.T) {
 	}
 
 	// minutes should contain 0,15,30,45
-	if !mapHas(parser.minutes, 0, 15, 30, 45) {
+	if !setHas(parser.minutes, 0, 15, 30, 45) {
 		t.Fatalf("minutes did not contain expected values, got %v", parser.minutes)
 	}
 
 	// hours should contain 1,2,3
-	if !mapHas(parser.hours, 1, 2, 3) {
+	if !setHas(parser.hours, 1, 2, 3) {
 		t.Fatalf("hours did not contain expected values, got %v", parser.hours)
 	}
 
 	// dayOfMonth should contain 1 and 15
-	if !mapHas(parser.dayOfMonth, 1, 15) {
+	if !setHas(parser.dayOfMonth, 1, 15) {
 		t.Fatalf("dayOfMonth did not contain expected values, got %v", parser.dayOfMonth)
 	}
 
 	// months and dayOfWeek should be non-empty (parsed from "*")
-	if len(parser.months) == 0 {
+	if parser.months.count() == 0 {
 		t.Fatalf("months should not be empty")
 	}
-	if len(parser.dayOfWeek) == 0 {
+	if parser.dayOfWeek.count() == 0 {
 		t.Fatalf("dayOfWeek should not be empty")
 	}
 }
@@ -75,7 +77,7 @@ func TestWithSeconds_Next(t *testing.T) {
 	}
 
 	// confirm seconds contains 0 and 30
-	if !mapHas(parser.seconds, 0, 30) {
+	if !setHas(parser.seconds, 0, 30) {
 		t.Fatalf("seconds did not contain expected values, got %v", parser.seconds)
 	}
 
@@ -153,11 +155,11 @@ func TestStep_AnyStep(t *testing.T) {
 
 	// minutes should contain 0,7,14,21,28,35,42,49,56
 	expected := []int{0, 7, 14, 21, 28, 35, 42, 49, 56}
-	if !mapHas(parser.minutes, expected...) {
+	if !setHas(parser.minutes, expected...) {
 		t.Fatalf("*/7 minutes did not contain expected values, got %v", parser.minutes)
 	}
-	if len(parser.minutes) != len(expected) {
-		t.Fatalf("*/7 minutes has wrong length: got %d, expected %d", len(parser.minutes), len(expected))
+	if parser.minutes.count() != len(expected) {
+		t.Fatalf("*/7 minutes has wrong length: got %d, expected %d", parser.minutes.count(), len(expected))
 	}
 }
 
@@ -169,11 +171,11 @@ func TestStep_RangeWithStep(t *testing.T) {
 	}
 
 	expected := []int{10, 15, 20, 25, 30}
-	if !mapHas(parser.minutes, expected...) {
+	if !setHas(parser.minutes, expected...) {
 		t.Fatalf("10-30/5 minutes did not contain expected values, got %v", parser.minutes)
 	}
-	if len(parser.minutes) != len(expected) {
-		t.Fatalf("10-30/5 minutes has wrong length: got %d, expected %d", len(parser.minutes), len(expected))
+	if parser.minutes.count() != len(expected) {
+		t.Fatalf("10-30/5 minutes has wrong length: got %d, expected %d", parser.minutes.count(), len(expected))
 	}
 }
 
@@ -185,11 +187,11 @@ func TestStep_StartWithStep(t *testing.T) {
 	}
 
 	expected := []int{15, 25, 35, 45, 55}
-	if !mapHas(parser.minutes, expected...) {
+	if !setHas(parser.minutes, expected...) {
 		t.Fatalf("15/10 minutes did not contain expected values, got %v", parser.minutes)
 	}
-	if len(parser.minutes) != len(expected) {
-		t.Fatalf("15/10 minutes has wrong length: got %d, expected %d", len(parser.minutes), len(expected))
+	if parser.minutes.count() != len(expected) {
+		t.Fatalf("15/10 minutes has wrong length: got %d, expected %d", parser.minutes.count(), len(expected))
 	}
 }
 
@@ -201,11 +203,11 @@ func TestStep_HoursField(t *testing.T) {
 	}
 
 	expected := []int{0, 8, 16}
-	if !mapHas(parser.hours, expected...) {
+	if !setHas(parser.hours, expected...) {
 		t.Fatalf("*/8 hours did not contain expected values, got %v", parser.hours)
 	}
-	if len(parser.hours) != len(expected) {
-		t.Fatalf("*/8 hours has wrong length: got %d, expected %d", len(parser.hours), len(expected))
+	if parser.hours.count() != len(expected) {
+		t.Fatalf("*/8 hours has wrong length: got %d, expected %d", parser.hours.count(), len(expected))
 	}
 }
 
@@ -217,11 +219,11 @@ func TestStep_SecondsField(t *testing.T) {
 	}
 
 	expected := []int{0, 7, 14, 21, 28, 35, 42, 49, 56}
-	if !mapHas(parser.seconds, expected...) {
+	if !setHas(parser.seconds, expected...) {
 		t.Fatalf("*/7 seconds did not contain expected values, got %v", parser.seconds)
 	}
-	if len(parser.seconds) != len(expected) {
-		t.Fatalf("*/7 seconds has wrong length: got %d, expected %d", len(parser.seconds), len(expected))
+	if parser.seconds.count() != len(expected) {
+		t.Fatalf("*/7 seconds has wrong length: got %d, expected %d", parser.seconds.count(), len(expected))
 	}
 }
 
@@ -233,11 +235,11 @@ func TestStep_RangeWithStep_Hours(t *testing.T) {
 	}
 
 	expected := []int{9, 11, 13, 15, 17}
-	if !mapHas(parser.hours, expected...) {
+	if !setHas(parser.hours, expected...) {
 		t.Fatalf("9-17/2 hours did not contain expected values, got %v", parser.hours)
 	}
-	if len(parser.hours) != len(expected) {
-		t.Fatalf("9-17/2 hours has wrong length: got %d, expected %d", len(parser.hours), len(expected))
+	if parser.hours.count() != len(expected) {
+		t.Fatalf("9-17/2 hours has wrong length: got %d, expected %d", parser.hours.count(), len(expected))
 	}
 }
 
@@ -256,8 +258,8 @@ func TestStep_InvalidStep(t *testing.T) {
 }
 
 func TestStep_InvalidRange(t *testing.T) {
-	// Range start > end should be rejected
-	_, err := newCronParser("30-10/5 * * * *", WithLocation(time.UTC))
+	// Range start > end should be rejected in day-of-month, where ranges do not wrap
+	_, err := newCronParser("0 0 30-10/5 * *", WithLocation(time.UTC))
 	if err == nil {
 		t.Fatalf("expected error for range start > end, got nil")
 	}
@@ -316,3 +318,150 @@ func TestStep_Next_WithRangeStep(t *testing.T) {
 		t.Fatalf("Next returned %v, expected %v", next2, expected2)
 	}
 }
+
+func TestNewCronParser_Names(t *testing.T) {
+	tests := []struct {
+		expr  string
+		field FieldType
+		want  []int
+	}{
+		{"0 9 * JAN-MAR *", Months, []int{1, 2, 3}},
+		{"0 9 * jul,Aug,DEC *", Months, []int{7, 8, 12}},
+		{"0 9 * FEB/4 *", Months, []int{2, 6, 10}},
+		{"0 9 * * MON-FRI", DayOfWeek, []int{1, 2, 3, 4, 5}},
+		{"0 9 * * sun,wed", DayOfWeek, []int{0, 3}},
+		{"0 9 * * MON-SAT/2", DayOfWeek, []int{1, 3, 5}},
+		{"0 9 * * 7", DayOfWeek, []int{0}},
+		{"0 9 * * 5-7", DayOfWeek, []int{0, 5, 6}},
+		{"0 9 * * 0,7", DayOfWeek, []int{0}},
+	}
+	for _, tt := range tests {
+		parser, err := newCronParser(tt.expr, WithLocation(time.UTC))
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.expr, err)
+			continue
+		}
+		set := parser.months
+		if tt.field == DayOfWeek {
+			set = parser.dayOfWeek
+		}
+		if set.count() != len(tt.want) || !setHas(set, tt.want...) {
+			t.Errorf("%q: got %v, want %v", tt.expr, set.values(), tt.want)
+		}
+	}
+}
+
+func TestNewCronParser_InvalidNames(t *testing.T) {
+	tests := []struct {
+		expr  string
+		token string
+	}{
+		{"0 9 * JANUARY *", "JANUARY"},
+		{"0 9 * * MON-FOO", "FOO"},
+		{"0 9 * MON *", "MON"}, // weekday name in the month field
+		{"0 9 * * 8", "8"},
+		{"0 JAN * * *", "JAN"},
+	}
+	for _, tt := range tests {
+		_, err := newCronParser(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.token) {
+			t.Errorf("%q: expected an error naming %q, got %v", tt.expr, tt.token, err)
+		}
+	}
+}
+
+func TestNext_Names(t *testing.T) {
+	parser, err := newCronParser("0 9 * JAN-MAR MON-FRI", WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error creating parser: %v", err)
+	}
+	// From Saturday, March 30, 2024 the next match is in January: Wednesday, Jan 1, 2025.
+	start := time.Date(2024, time.March, 30, 12, 0, 0, 0, time.UTC)
+	expected := time.Date(2025, time.January, 1, 9, 0, 0, 0, time.UTC)
+	if next := parser.Next(start); !next.Equal(expected) {
+		t.Fatalf("Next returned %v, expected %v", next, expected)
+	}
+
+	// 7 and SUN both mean Sunday, also for the last-weekday form.
+	for _, expr := range []string{"0 0 * * 7L", "0 0 * * SUNL", "0 0 * * 0L"} {
+		parser, err := newCronParser(expr, WithLocation(time.UTC))
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", expr, err)
+		}
+		next := parser.Next(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC))
+		if expected := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC); !next.Equal(expected) {
+			t.Errorf("%q: Next returned %v, expected %v", expr, next, expected)
+		}
+	}
+}
+
+func TestNewCronParser_WrapAroundRanges(t *testing.T) {
+	tests := []struct {
+		expr  string
+		opts  []Option
+		field FieldType
+		want  []int
+	}{
+		{"0 22-2 * * *", nil, Hours, []int{0, 1, 2, 22, 23}},
+		{"0 22-6/2 * * *", nil, Hours, []int{0, 2, 4, 6, 22}},
+		{"50-5/5 * * * *", nil, Minutes, []int{0, 5, 50, 55}},
+		{"58-1 0 * * * *", []Option{WithSeconds()}, Seconds, []int{0, 1, 58, 59}},
+		{"0 0 * NOV-FEB *", nil, Months, []int{1, 2, 11, 12}},
+		{"0 0 * 12-1 *", nil, Months, []int{1, 12}},
+		{"0 0 * * FRI-MON", nil, DayOfWeek, []int{0, 1, 5, 6}},
+		{"0 0 * * 6-1", nil, DayOfWeek, []int{0, 1, 6}},
+		{"0 0 * * 7-2", nil, DayOfWeek, []int{0, 1, 2}},
+		{"0 0 * * 5-0/2", nil, DayOfWeek, []int{0, 5}},
+	}
+	for _, tt := range tests {
+		parser, err := newCronParser(tt.expr, append([]Option{WithLocation(time.UTC)}, tt.opts...)...)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.expr, err)
+			continue
+		}
+		var got []int
+		switch tt.field {
+		case Seconds:
+			got = parser.seconds.values()
+		case Minutes:
+			got = parser.minutes.values()
+		case Hours:
+			got = parser.hours.values()
+		case Months:
+			got = parser.months.values()
+		case DayOfWeek:
+			got = parser.dayOfWeek.values()
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	// Day-of-month and year ranges do not wrap.
+	if _, err := newCronParser("0 0 25-5 * *"); err == nil {
+		t.Error("expected an error for a day-of-month range with start > end")
+	}
+	if _, err := newCronParser("0 0 1 1 * 2030-2025", WithYears()); err == nil {
+		t.Error("expected an error for a year range with start > end")
+	}
+}
+
+func TestNext_WrapAroundRange(t *testing.T) {
+	parser, err := newCronParser("0 22-2 * * *", WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error creating parser: %v", err)
+	}
+	next := time.Date(2023, time.December, 31, 21, 0, 0, 0, time.UTC)
+	for _, want := range []time.Time{
+		time.Date(2023, time.December, 31, 22, 0, 0, 0, time.UTC),
+		time.Date(2023, time.December, 31, 23, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 1, 1, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 1, 2, 0, 0, 0, time.UTC),
+		time.Date(2024, time.January, 1, 22, 0, 0, 0, time.UTC),
+	} {
+		if next = parser.Next(next); !next.Equal(want) {
+			t.Fatalf("Next returned %v, expected %v", next, want)
+		}
+	}
+}
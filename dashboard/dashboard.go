@@ -0,0 +1,216 @@
+// Package dashboard serves a self-contained HTML dashboard for a scheduler.
+//
+// The page lists every task with its expression in plain English, upcoming fire
+// times, running state and recent outcomes, with buttons to pause, resume or run
+// a task now. Templates and styles are embedded; no JavaScript is used.
+//
+// Mount it under a prefix with http.StripPrefix, keeping the trailing slash:
+//
+//	mux.Handle("/dashboard/", http.StripPrefix("/dashboard", dashboard.NewHandler(scheduler)))
+//
+// The handler performs control operations on POST requests and rejects cross-origin
+// form submissions. WithAuthenticator authenticates callers as admin.NewHandler does:
+// the page requires admin.RoleReadOnly, the buttons admin.RoleOperator, and
+// operations are audited with the principal's name as actor.
+//
+// Without WithAuthenticator the dashboard is unauthenticated: anyone who can reach
+// it can pause, resume and run tasks, audited as Actor. Only serve it that way
+// behind a proxy that authenticates users.
+package dashboard
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+	"github.com/hansir-hsj/GoLiteCron/admin"
+)
+
+// Actor is the audit actor recorded for operations performed from a dashboard
+// without authenticator.
+const Actor = "dashboard"
+
+const (
+	upcomingRuns     = 5
+	recentExecutions = 5
+	refreshInterval  = 10 * time.Second
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+//go:embed static
+var staticFS embed.FS
+
+var pageTemplate = template.Must(template.New("index.html").Funcs(template.FuncMap{
+	"pathEscape": url.PathEscape,
+	"formatTime": formatTime,
+	"formatDuration": func(d time.Duration) string {
+		return d.Round(time.Millisecond).String()
+	},
+}).ParseFS(templateFS, "templates/index.html"))
+
+// Option configures the dashboard handler.
+type Option func(*handler)
+
+// WithAuthenticator requires requests to authenticate with a and authorizes them by
+// role, as admin.WithAuthenticator does for the admin API.
+func WithAuthenticator(a admin.Authenticator) Option {
+	return func(h *handler) {
+		h.auth = a
+	}
+}
+
+// NewHandler returns an http.Handler serving the dashboard for s.
+func NewHandler(s *cron.Scheduler, opts ...Option) http.Handler {
+	h := &handler{scheduler: s}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	static, _ := fs.Sub(staticFS, "static")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", h.authorize(admin.RoleReadOnly, h.index))
+	mux.Handle("GET /static/", http.StripPrefix("/static", http.FileServer(http.FS(static))))
+	mux.HandleFunc("POST /tasks/{id}/pause", h.authorize(admin.RoleOperator, h.control((*cron.Controller).PauseTask)))
+	mux.HandleFunc("POST /tasks/{id}/resume", h.authorize(admin.RoleOperator, h.control((*cron.Controller).ResumeTask)))
+	mux.HandleFunc("POST /tasks/{id}/run", h.authorize(admin.RoleOperator, h.control((*cron.Controller).TriggerTask)))
+	return mux
+}
+
+type handler struct {
+	scheduler *cron.Scheduler
+	auth      admin.Authenticator
+}
+
+type principalKey struct{}
+
+// authorize authenticates the request and checks that the caller has at least role.
+func (h *handler) authorize(role admin.Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.auth == nil {
+			next(w, r)
+			return
+		}
+
+		p, err := h.auth.Authenticate(r)
+		switch {
+		case errors.Is(err, admin.ErrNoCredentials), errors.Is(err, admin.ErrInvalidCredentials):
+			w.Header().Set("WWW-Authenticate", `Bearer realm="golitecron"`)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case p.Role < role:
+			http.Error(w, "role "+p.Role.String()+" may not perform this operation", http.StatusForbidden)
+			return
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, p)))
+	}
+}
+
+// actor returns the audit actor of a request: the authenticated principal, or Actor.
+func actor(r *http.Request) (name string, role admin.Role) {
+	if p, ok := r.Context().Value(principalKey{}).(admin.Principal); ok {
+		return p.Name, p.Role
+	}
+	return Actor, admin.RoleOperator
+}
+
+type page struct {
+	Generated      time.Time
+	RefreshSeconds int
+	CanControl     bool // whether the caller may use the buttons
+	Error          string
+	Health         cron.HealthReport
+	Tasks          []taskView
+}
+
+type taskView struct {
+	cron.TaskInfo
+	Upcoming []time.Time
+	Recent   []cron.ExecutionRecord
+}
+
+func (h *handler) index(w http.ResponseWriter, r *http.Request) {
+	_, role := actor(r)
+	infos := h.scheduler.Tasks()
+	p := page{
+		Generated:      time.Now(),
+		RefreshSeconds: int(refreshInterval / time.Second),
+		CanControl:     role >= admin.RoleOperator,
+		Error:          r.URL.Query().Get("error"),
+		Health:         h.scheduler.Health(),
+		Tasks:          make([]taskView, 0, len(infos)),
+	}
+	for _, info := range infos {
+		recent := h.scheduler.RecentExecutions(info.ID)
+		if len(recent) > recentExecutions {
+			recent = recent[:recentExecutions]
+		}
+		p.Tasks = append(p.Tasks, taskView{
+			TaskInfo: info,
+			Upcoming: h.scheduler.UpcomingRuns(info.ID, upcomingRuns),
+			Recent:   recent,
+		})
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := pageTemplate.Execute(w, p); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// control wraps a task control operation as a form handler that redirects back to the page.
+// Operations are audited with the authenticated principal's name, or Actor, as the actor.
+func (h *handler) control(op func(c *cron.Controller, taskID string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !sameOrigin(r) {
+			http.Error(w, "cross-origin request rejected", http.StatusForbidden)
+			return
+		}
+
+		// The page is two levels up from /tasks/{id}/{action}. The Location is set
+		// directly since http.Redirect would resolve it against the stripped path.
+		target := "../../"
+		name, _ := actor(r)
+		if err := op(h.scheduler.AsActor(name), r.PathValue("id")); err != nil {
+			target += "?error=" + url.QueryEscape(err.Error())
+		}
+		w.Header().Set("Location", target)
+		w.WriteHeader(http.StatusSeeOther)
+	}
+}
+
+// sameOrigin reports whether a form submission comes from a page served by this host.
+// Requests without Origin or Sec-Fetch-Site headers, e.g. from curl, are allowed.
+func sameOrigin(r *http.Request) bool {
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "", "same-origin", "none":
+	default:
+		return false
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "—"
+	}
+	return t.Format("2006-01-02 15:04:05 MST")
+}
@@ -0,0 +1,209 @@
+package dashboard
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+	"github.com/hansir-hsj/GoLiteCron/admin"
+)
+
+func newTestServer(t *testing.T) (*cron.Scheduler, *httptest.Server) {
+	t.Helper()
+	s := cron.NewScheduler()
+	job, _ := cron.WrapJob("report", func() error { return nil })
+	if err := s.AddTask("30 9 * * 1-5", job, cron.WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/dashboard/", http.StripPrefix("/dashboard", NewHandler(s)))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return s, srv
+}
+
+// noRedirect is a client that returns redirects instead of following them.
+var noRedirect = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+}
+
+func get(t *testing.T, url string) (*http.Response, string) {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(data)
+}
+
+func post(t *testing.T, url, origin string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	resp, err := noRedirect.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestDashboard_Index(t *testing.T) {
+	_, srv := newTestServer(t)
+
+	resp, body := get(t, srv.URL+"/dashboard/")
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Fatalf("status %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	for _, want := range []string{
+		"report",
+		"30 9 * * 1-5",
+		"At 09:30, on Monday through Friday",
+		`action="tasks/report/pause"`,
+		`action="tasks/report/run"`,
+		"no executions yet",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("page does not contain %q", want)
+		}
+	}
+
+	resp, body = get(t, srv.URL+"/dashboard/static/style.css")
+	if resp.StatusCode != http.StatusOK || !strings.Contains(body, ".task") {
+		t.Errorf("stylesheet: status %d", resp.StatusCode)
+	}
+}
+
+func TestDashboard_Controls(t *testing.T) {
+	s, srv := newTestServer(t)
+
+	resp := post(t, srv.URL+"/dashboard/tasks/report/pause", "")
+	if resp.StatusCode != http.StatusSeeOther || resp.Header.Get("Location") != "../../" {
+		t.Fatalf("pause: status %d, location %q", resp.StatusCode, resp.Header.Get("Location"))
+	}
+	if info, _ := s.Task("report"); info.Status != cron.TaskStatusPaused {
+		t.Fatalf("expected paused task, got %s", info.Status)
+	}
+	if _, body := get(t, srv.URL+"/dashboard/"); !strings.Contains(body, `action="tasks/report/resume"`) {
+		t.Error("paused task should offer resume")
+	}
+
+	post(t, srv.URL+"/dashboard/tasks/report/resume", "")
+	if info, _ := s.Task("report"); info.Status != cron.TaskStatusScheduled {
+		t.Fatalf("expected scheduled task, got %s", info.Status)
+	}
+
+	// Running now on a stopped scheduler reports the error on the page.
+	resp = post(t, srv.URL+"/dashboard/tasks/report/run", "")
+	loc := resp.Header.Get("Location")
+	if !strings.Contains(loc, "error=") {
+		t.Fatalf("expected error redirect, got %q", loc)
+	}
+	if _, body := get(t, srv.URL+"/dashboard/tasks/report/"+loc); !strings.Contains(body, "scheduler is not running") {
+		t.Error("page should show the error")
+	}
+}
+
+func TestDashboard_RejectsCrossOrigin(t *testing.T) {
+	s, srv := newTestServer(t)
+
+	if resp := post(t, srv.URL+"/dashboard/tasks/report/pause", "https://evil.example"); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", resp.StatusCode)
+	}
+	if info, _ := s.Task("report"); info.Status != cron.TaskStatusScheduled {
+		t.Errorf("cross-origin request should not pause the task")
+	}
+	if resp := post(t, srv.URL+"/dashboard/tasks/report/pause", srv.URL); resp.StatusCode != http.StatusSeeOther {
+		t.Errorf("same-origin request: status %d", resp.StatusCode)
+	}
+}
+
+type recordingSink struct {
+	mu     sync.Mutex
+	events []cron.AuditEvent
+}
+
+func (r *recordingSink) WriteAudit(e cron.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+	return nil
+}
+
+func TestDashboard_Authenticator(t *testing.T) {
+	s := cron.NewScheduler()
+	job, _ := cron.WrapJob("report", func() error { return nil })
+	if err := s.AddTask("30 9 * * 1-5", job, cron.WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	sink := &recordingSink{}
+	s.WithAuditSink(sink)
+	auth := admin.BearerTokens{
+		"viewer-token":   {Name: "viewer", Role: admin.RoleReadOnly},
+		"operator-token": {Name: "alice", Role: admin.RoleOperator},
+	}
+	srv := httptest.NewServer(NewHandler(s, WithAuthenticator(auth)))
+	t.Cleanup(srv.Close)
+
+	do := func(method, path, token string) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(method, srv.URL+path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := noRedirect.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(data)
+	}
+
+	cases := []struct {
+		method, path, token string
+		status              int
+	}{
+		{http.MethodGet, "/", "", http.StatusUnauthorized},
+		{http.MethodGet, "/", "viewer-token", http.StatusOK},
+		{http.MethodPost, "/tasks/report/pause", "", http.StatusUnauthorized},
+		{http.MethodPost, "/tasks/report/pause", "viewer-token", http.StatusForbidden},
+		{http.MethodPost, "/tasks/report/pause", "operator-token", http.StatusSeeOther},
+	}
+	for _, c := range cases {
+		if got, _ := do(c.method, c.path, c.token); got != c.status {
+			t.Errorf("%s %s with %q: status %d, want %d", c.method, c.path, c.token, got, c.status)
+		}
+	}
+	if len(sink.events) != 1 || sink.events[0].Actor != "alice" || sink.events[0].Action != cron.AuditPause {
+		t.Errorf("expected one pause by alice, got %+v", sink.events)
+	}
+
+	// Read-only principals are not offered the buttons.
+	if _, body := do(http.MethodGet, "/", "viewer-token"); strings.Contains(body, `action="tasks/report/resume"`) {
+		t.Error("read-only page should not offer controls")
+	}
+	if _, body := do(http.MethodGet, "/", "operator-token"); !strings.Contains(body, `action="tasks/report/resume"`) {
+		t.Error("operator page should offer controls")
+	}
+}
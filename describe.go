@@ -0,0 +1,325 @@
+package golitecron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fieldUnit names the values of a field for descriptions.
+type fieldUnit struct {
+	singular string
+	plural   string
+	name     func(int) string // optional value formatter, e.g. weekday names
+	names    map[string]int   // names accepted in the field, e.g. "MON"
+}
+
+var (
+	secondUnit = fieldUnit{singular: "second", plural: "seconds"}
+	minuteUnit = fieldUnit{singular: "minute", plural: "minutes"}
+	hourUnit   = fieldUnit{singular: "hour", plural: "hours"}
+	dayUnit    = fieldUnit{singular: "day", plural: "days"}
+	yearUnit   = fieldUnit{singular: "year", plural: "years"}
+	monthUnit  = fieldUnit{singular: "month", plural: "months", names: monthNames, name: func(v int) string {
+		return time.Month(v).String()
+	}}
+	weekdayUnit = fieldUnit{singular: "day of the week", plural: "days of the week", names: weekdayNames, name: func(v int) string {
+		return time.Weekday(v % 7).String()
+	}}
+)
+
+// describe renders the schedule in plain English, e.g.
+// "At 09:30, on Monday through Friday" for "30 9 * * 1-5".
+func (p *CronParser) describe() string {
+	parts := []string{p.describeTime()}
+	if days := p.describeDays(); days != "" {
+		parts = append(parts, days)
+	}
+	if months := p.fields[Months]; !isWildcard(months) {
+		parts = append(parts, "in "+describeList(months, monthUnit))
+	}
+	if years := p.fields[Years]; p.enableYears && !isWildcard(years) {
+		parts = append(parts, "in "+describeList(years, yearUnit))
+	}
+
+	desc := strings.Join(parts, ", ")
+	return strings.ToUpper(desc[:1]) + desc[1:]
+}
+
+func (p *CronParser) describeTime() string {
+	second, minute, hour := "0", p.fields[Minutes], p.fields[Hours]
+	if p.enableSeconds {
+		second = p.fields[Seconds]
+	}
+
+	// Fixed times of day, e.g. "At 09:00 and 17:00".
+	s, sOK := plainInt(second)
+	m, mOK := plainInt(minute)
+	if hours, ok := plainInts(hour); sOK && mOK && ok {
+		times := make([]string, len(hours))
+		for i, h := range hours {
+			times[i] = clock(h, m, s, p.enableSeconds)
+		}
+		return "at " + joinAnd(times)
+	}
+
+	var parts []string
+	if p.enableSeconds && second != "0" {
+		parts = append(parts, describeTimeList(second, secondUnit))
+	}
+
+	switch {
+	case isWildcard(minute):
+		if len(parts) == 0 {
+			parts = append(parts, "every minute")
+		}
+	case mOK && isWildcard(hour):
+		if m == 0 {
+			parts = append(parts, "every hour")
+		} else {
+			parts = append(parts, fmt.Sprintf("at %d minutes past every hour", m))
+		}
+	case mOK:
+		if m == 0 {
+			parts = append(parts, "at the start of the hour")
+		} else {
+			parts = append(parts, fmt.Sprintf("at %d minutes past the hour", m))
+		}
+	default:
+		parts = append(parts, describeTimeList(minute, minuteUnit))
+	}
+
+	if !isWildcard(hour) {
+		parts = append(parts, describeHours(hour))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeTimeList renders a seconds or minutes field, e.g. "at seconds 0 and 30".
+func describeTimeList(field string, unit fieldUnit) string {
+	if _, ok := plainInts(field); ok {
+		return "at " + describeList(field, unit)
+	}
+	return describeList(field, unit)
+}
+
+// describeHours renders the hour field as time windows where that reads better.
+func describeHours(field string) string {
+	if h, ok := plainInt(field); ok {
+		return fmt.Sprintf("between %02d:00 and %02d:59", h, h)
+	}
+	if lo, hi, ok := plainRange(field); ok {
+		return fmt.Sprintf("between %02d:00 and %02d:59", lo, hi)
+	}
+	return describeList(field, hourUnit)
+}
+
+func (p *CronParser) describeDays() string {
+	dom, dow := p.fields[DayOfMonth], p.fields[DayOfWeek]
+	var parts []string
+	if !p.dayOfMonthWildcard {
+		parts = append(parts, describeDayOfMonth(dom))
+	}
+	if !p.dayOfWeekWildcard {
+		parts = append(parts, describeDayOfWeek(dow))
+	}
+	// Day-of-month and day-of-week restrictions are ORed, as in standard cron.
+	return strings.Join(parts, " or ")
+}
+
+func describeDayOfMonth(field string) string {
+	if _, ok := plainInts(field); ok {
+		return "on " + describeList(field, dayUnit) + " of the month"
+	}
+
+	items := strings.Split(field, ",")
+	descs := make([]string, len(items))
+	for i, item := range items {
+		switch {
+		case item == "L":
+			descs[i] = "the last day of the month"
+		case item == "LW":
+			descs[i] = "the last weekday of the month"
+		case strings.HasPrefix(item, "L-"):
+			n := strings.TrimPrefix(item, "L-")
+			if n == "1" {
+				descs[i] = "1 day before the last day of the month"
+			} else {
+				descs[i] = n + " days before the last day of the month"
+			}
+		case strings.HasSuffix(item, "W"):
+			descs[i] = fmt.Sprintf("the weekday nearest day %s of the month", strings.TrimSuffix(item, "W"))
+		default:
+			descs[i] = describeItem(item, dayUnit) + " of the month"
+		}
+	}
+	return "on " + joinAnd(descs)
+}
+
+func describeDayOfWeek(field string) string {
+	items := strings.Split(field, ",")
+	descs := make([]string, len(items))
+	for i, item := range items {
+		if n, ok := strings.CutSuffix(item, "L"); ok && n != "" {
+			descs[i] = "the last " + describeItem(n, weekdayUnit) + " of the month"
+			continue
+		}
+		if wd, nth, ok := strings.Cut(item, "#"); ok {
+			k, _ := strconv.Atoi(nth)
+			descs[i] = "the " + ordinal(k) + " " + describeItem(wd, weekdayUnit) + " of the month"
+			continue
+		}
+		descs[i] = describeItem(item, weekdayUnit)
+	}
+	return "on " + joinAnd(descs)
+}
+
+// describeList renders a comma-separated field, merging plain values into one phrase.
+func describeList(field string, unit fieldUnit) string {
+	if values, ok := plainInts(field); ok && len(values) > 1 {
+		names := make([]string, len(values))
+		for i, v := range values {
+			names[i] = unit.format(v)
+		}
+		if unit.name != nil {
+			return joinAnd(names)
+		}
+		return unit.plural + " " + joinAnd(names)
+	}
+
+	items := strings.Split(field, ",")
+	descs := make([]string, len(items))
+	for i, item := range items {
+		descs[i] = describeItem(item, unit)
+	}
+	return joinAnd(descs)
+}
+
+// describeItem renders a single list element: a value, range or step.
+func describeItem(item string, unit fieldUnit) string {
+	if isWildcard(item) {
+		return "every " + unit.singular
+	}
+
+	if base, stepStr, ok := strings.Cut(item, "/"); ok {
+		step, _ := strconv.Atoi(stepStr)
+		every := fmt.Sprintf("every %d %s", step, unit.plural)
+		if step == 1 {
+			every = "every " + unit.singular
+		}
+		switch {
+		case isWildcard(base):
+			return every
+		case strings.Contains(base, "-"):
+			return every + " from " + describeRange(base, unit, false)
+		default:
+			return every + " starting at " + unit.formatText(base)
+		}
+	}
+
+	if strings.Contains(item, "-") {
+		return describeRange(item, unit, true)
+	}
+	if unit.name != nil {
+		return unit.formatText(item)
+	}
+	return unit.singular + " " + item
+}
+
+func describeRange(r string, unit fieldUnit, withUnit bool) string {
+	lo, hi, _ := strings.Cut(r, "-")
+	desc := unit.formatText(lo) + " through " + unit.formatText(hi)
+	if withUnit && unit.name == nil {
+		return unit.plural + " " + desc
+	}
+	return desc
+}
+
+func (u fieldUnit) format(v int) string {
+	if u.name != nil {
+		return u.name(v)
+	}
+	return strconv.Itoa(v)
+}
+
+// formatText formats a numeric or named token, leaving anything else as written.
+func (u fieldUnit) formatText(tok string) string {
+	if v, err := strconv.Atoi(tok); err == nil {
+		return u.format(v)
+	}
+	if v, ok := u.names[strings.ToUpper(tok)]; ok {
+		return u.format(v)
+	}
+	return tok
+}
+
+func isWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+func plainInt(field string) (int, bool) {
+	v, err := strconv.Atoi(field)
+	return v, err == nil
+}
+
+// plainInts parses a list of plain values, e.g. "9,12,17".
+func plainInts(field string) ([]int, bool) {
+	items := strings.Split(field, ",")
+	values := make([]int, len(items))
+	for i, item := range items {
+		v, ok := plainInt(item)
+		if !ok {
+			return nil, false
+		}
+		values[i] = v
+	}
+	return values, true
+}
+
+// plainRange parses a range without step, e.g. "9-17".
+func plainRange(field string) (int, int, bool) {
+	loStr, hiStr, ok := strings.Cut(field, "-")
+	if !ok {
+		return 0, 0, false
+	}
+	lo, loOK := plainInt(loStr)
+	hi, hiOK := plainInt(hiStr)
+	return lo, hi, loOK && hiOK
+}
+
+func clock(h, m, s int, withSeconds bool) string {
+	if withSeconds {
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", h, m)
+}
+
+func ordinal(n int) string {
+	switch n {
+	case 1:
+		return "first"
+	case 2:
+		return "second"
+	case 3:
+		return "third"
+	case 4:
+		return "fourth"
+	case 5:
+		return "fifth"
+	default:
+		return strconv.Itoa(n) + "th"
+	}
+}
+
+// joinAnd joins items as "a, b and c".
+func joinAnd(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+	}
+}
@@ -0,0 +1,53 @@
+package golitecron
+
+import "testing"
+
+// TestDescribe tests the plain-English rendering of cron expressions
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		expr string
+		opts []Option
+		want string
+	}{
+		{"* * * * *", nil, "Every minute"},
+		{"0 * * * *", nil, "Every hour"},
+		{"15 * * * *", nil, "At 15 minutes past every hour"},
+		{"*/5 * * * *", nil, "Every 5 minutes"},
+		{"0,30 * * * *", nil, "At minutes 0 and 30"},
+		{"30 9 * * 1-5", nil, "At 09:30, on Monday through Friday"},
+		{"0 9,17 * * *", nil, "At 09:00 and 17:00"},
+		{"0 9-17 * * *", nil, "At the start of the hour, between 09:00 and 17:59"},
+		{"*/10 9 * * *", nil, "Every 10 minutes, between 09:00 and 09:59"},
+		{"0 0 1,15 * *", nil, "At 00:00, on days 1 and 15 of the month"},
+		{"0 0 L * *", nil, "At 00:00, on the last day of the month"},
+		{"0 0 15W * *", nil, "At 00:00, on the weekday nearest day 15 of the month"},
+		{"0 0 * * 5L", nil, "At 00:00, on the last Friday of the month"},
+		{"0 0 1 * 0", nil, "At 00:00, on day 1 of the month or on Sunday"},
+		{"0 0 1 1,7 *", nil, "At 00:00, on day 1 of the month, in January and July"},
+		{"0 12 * 6-8 *", nil, "At 12:00, in June through August"},
+		{"0 9 * JAN-MAR MON-FRI", nil, "At 09:00, on Monday through Friday, in January through March"},
+		{"0 0 * * sun,Wed", nil, "At 00:00, on Sunday and Wednesday"},
+		{"0 0 * * 7", nil, "At 00:00, on Sunday"},
+		{"0 0 * * FRIL", nil, "At 00:00, on the last Friday of the month"},
+		{"0 0 * * 5#3", nil, "At 00:00, on the third Friday of the month"},
+		{"0 0 * * 1#1,FRI#3", nil, "At 00:00, on the first Monday of the month and the third Friday of the month"},
+		{"0 22-2 * * *", nil, "At the start of the hour, between 22:00 and 02:59"},
+		{"0 0 * * FRI-MON", nil, "At 00:00, on Friday through Monday"},
+		{"0 0 LW * *", nil, "At 00:00, on the last weekday of the month"},
+		{"0 0 L-3 * *", nil, "At 00:00, on 3 days before the last day of the month"},
+		{"@daily", nil, "At 00:00"},
+		{"*/15 * * * * *", []Option{WithSeconds()}, "Every 15 seconds"},
+		{"30 0 8 * * *", []Option{WithSeconds()}, "At 08:00:30"},
+		{"0 0 1 1 * 2030", []Option{WithYears()}, "At 00:00, on day 1 of the month, in January, in year 2030"},
+	}
+
+	for _, tt := range tests {
+		p, err := newCronParser(tt.expr, tt.opts...)
+		if err != nil {
+			t.Fatalf("%q: parse failed: %v", tt.expr, err)
+		}
+		if got := p.describe(); got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
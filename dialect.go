@@ -0,0 +1,143 @@
+package golitecron
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Dialect selects the syntax expressions are parsed with.
+type Dialect int
+
+const (
+	// DialectStandard parses 5 fields, plus seconds and years as enabled by
+	// WithSeconds and WithYears.
+	DialectStandard Dialect = iota
+	// DialectAuto infers the fields from their count: 5 are standard, 6 start with
+	// seconds and 7 also end with years. WithSeconds and WithYears are ignored.
+	DialectAuto
+	// DialectQuartz parses Quartz expressions: seconds first, an optional trailing
+	// year, ? in exactly one of day of month and day of week, and days of the week
+	// numbered 1-7 from SUN=1.
+	DialectQuartz
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case DialectStandard:
+		return "standard"
+	case DialectAuto:
+		return "auto"
+	case DialectQuartz:
+		return "quartz"
+	default:
+		return fmt.Sprintf("Dialect(%d)", int(d))
+	}
+}
+
+// ParseDialect returns the dialect named "standard", "auto" or "quartz", ignoring
+// case. The empty string is standard.
+func ParseDialect(name string) (Dialect, error) {
+	switch strings.ToLower(name) {
+	case "", "standard":
+		return DialectStandard, nil
+	case "auto":
+		return DialectAuto, nil
+	case "quartz":
+		return DialectQuartz, nil
+	default:
+		return 0, fmt.Errorf("unknown dialect %q: want standard, auto or quartz", name)
+	}
+}
+
+// WithDialect sets the syntax of the expression; the default is DialectStandard.
+// Macros such as @daily are accepted in every dialect.
+func WithDialect(d Dialect) Option {
+	return func(p *CronParser) {
+		p.dialect = d
+	}
+}
+
+// applyDialect enables the seconds and years fields for the fields of an expression
+// and rewrites Quartz fields into standard syntax.
+func (p *CronParser) applyDialect(d Dialect, parts []string) error {
+	switch d {
+	case DialectAuto:
+		if len(parts) < 5 || len(parts) > 7 {
+			return fmt.Errorf("invalid cron expression length: expected 5, 6 or 7 fields, got %d", len(parts))
+		}
+		p.enableSeconds = len(parts) >= 6
+		p.enableYears = len(parts) == 7
+	case DialectQuartz:
+		if len(parts) != 6 && len(parts) != 7 {
+			return fmt.Errorf("invalid Quartz expression length: expected 6 or 7 fields (Seconds Minutes Hours DayOfMonth Months DayOfWeek [Years]), got %d", len(parts))
+		}
+		p.enableSeconds = true
+		p.enableYears = len(parts) == 7
+		if (parts[3] == "?") == (parts[5] == "?") {
+			return fmt.Errorf("invalid Quartz expression: exactly one of DayOfMonth %q and DayOfWeek %q must be ?", parts[3], parts[5])
+		}
+		dow, err := quartzWeekdays(parts[5])
+		if err != nil {
+			return fmt.Errorf("error parsing %s field %q (allowed 1-7 or SUN-SAT): %v", DayOfWeek, parts[5], err)
+		}
+		parts[5] = dow
+	}
+	return nil
+}
+
+var weekdayNumber = regexp.MustCompile(`[0-9]+`)
+
+// quartzWeekdays renumbers a Quartz day-of-week field from SUN=1 to SUN=0, e.g.
+// "2-6" to "1-5" and "6#3" to "5#3". Step sizes and # occurrences are kept, and a
+// lone L, the last day of the week, becomes SAT.
+func quartzWeekdays(field string) (string, error) {
+	return renumberWeekdays(field, func(n int) (int, error) {
+		if n < 1 || n > 7 {
+			return 0, fmt.Errorf("invalid value: %d", n)
+		}
+		return n - 1, nil
+	})
+}
+
+// quartzNumbering renumbers a standard day-of-week field back to Quartz numbering,
+// the inverse of quartzWeekdays, so that resolved Quartz expressions parse again.
+func quartzNumbering(field string) string {
+	field, _ = renumberWeekdays(field, func(n int) (int, error) {
+		return n%7 + 1, nil // 7 is also Sunday
+	})
+	return field
+}
+
+// renumberWeekdays applies renumber to the weekdays of a day-of-week field, leaving
+// names, step sizes and # occurrences alone.
+func renumberWeekdays(field string, renumber func(int) (int, error)) (string, error) {
+	items := strings.Split(field, ",")
+	for i, item := range items {
+		if item == "L" {
+			items[i] = "SAT"
+			continue
+		}
+		end := len(item)
+		if j := strings.IndexAny(item, "/#"); j >= 0 {
+			end = j
+		}
+		var err error
+		base := weekdayNumber.ReplaceAllStringFunc(item[:end], func(num string) string {
+			n, convErr := strconv.Atoi(num)
+			if convErr == nil {
+				n, convErr = renumber(n)
+			}
+			if convErr != nil && err == nil {
+				err = fmt.Errorf("invalid value: %s", num)
+			}
+			return strconv.Itoa(n)
+		})
+		if err != nil {
+			return "", err
+		}
+		items[i] = base + item[end:]
+	}
+	return strings.Join(items, ","), nil
+}
@@ -0,0 +1,213 @@
+package golitecron
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestDialect_Auto tests that the seconds and years fields are inferred from the field count
+func TestDialect_Auto(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		expr           string
+		seconds, years bool
+		next           time.Time
+	}{
+		{"30 9 * * *", false, false, time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)},
+		{"15 30 9 * * *", true, false, time.Date(2024, 1, 1, 9, 30, 15, 0, time.UTC)},
+		{"15 30 9 * * * 2030", true, true, time.Date(2030, 1, 1, 9, 30, 15, 0, time.UTC)},
+		{"@daily", false, false, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		// WithSeconds is overridden by the field count.
+		s, err := Parse(tt.expr, WithDialect(DialectAuto), WithSeconds(), WithLocation(time.UTC))
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.expr, err)
+			continue
+		}
+		if s.parser.enableSeconds != tt.seconds || s.parser.enableYears != tt.years {
+			t.Errorf("Parse(%q): seconds %v years %v, want %v %v", tt.expr,
+				s.parser.enableSeconds, s.parser.enableYears, tt.seconds, tt.years)
+		}
+		if got := s.Next(from); !got.Equal(tt.next) {
+			t.Errorf("Parse(%q).Next = %v, want %v", tt.expr, got, tt.next)
+		}
+	}
+
+	for _, expr := range []string{"* * * *", "0 0 0 * * * 2030 1"} {
+		if _, err := Parse(expr, WithDialect(DialectAuto)); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("Parse(%q): expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+}
+
+// TestDialect_Quartz tests Quartz expressions against their standard equivalents
+func TestDialect_Quartz(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		quartz, standard string
+	}{
+		{"0 0 12 ? * MON-FRI", "0 0 12 * * MON-FRI"},
+		{"0 0 12 ? * 2-6", "0 0 12 * * 1-5"},
+		{"0 0 9 ? * 1", "0 0 9 * * 0"},
+		{"0 0 9 ? * 7", "0 0 9 * * 6"},
+		{"0 0 9 ? * 1,7", "0 0 9 * * 0,6"},
+		{"0 0 9 ? * L", "0 0 9 * * 6"},
+		{"0 0 9 ? * 7-2", "0 0 9 * * 6-1"},
+		{"0 0 9 ? * 1/3", "0 0 9 * * 0,3,6"},
+		{"0 0 9 ? * 2-6/2", "0 0 9 * * 1,3,5"},
+		{"0 15 10 ? * 6L", "0 15 10 * * 5L"},
+		{"0 15 10 ? * 1L", "0 15 10 * * 0L"},
+		{"0 15 10 ? * 6#3", "0 15 10 * * 5#3"},
+		{"0 15 10 ? * 1#1", "0 15 10 * * 0#1"},
+		{"0 0/15 8-10 ? * TUE,THU", "0 */15 8-10 * * 2,4"},
+		{"0 0 12 1/5 * ?", "0 0 12 1/5 * *"},
+		{"0 15 10 L-2 * ?", "0 15 10 L-2 * *"},
+		{"0 0 9 LW * ?", "0 0 9 LW * *"},
+		{"0 0 9 15W * ?", "0 0 9 15W * *"},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.quartz, WithDialect(DialectQuartz), WithLocation(time.UTC))
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.quartz, err)
+			continue
+		}
+		s, err := Parse(tt.standard, WithSeconds(), WithLocation(time.UTC))
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.standard, err)
+		}
+		want := s.NextN(from, 30)
+		if got := q.NextN(from, 30); !slices.EqualFunc(got, want, time.Time.Equal) {
+			t.Errorf("%q fires at %v, want %v as %q", tt.quartz, got, want, tt.standard)
+		}
+
+		// The resolved expression parses again to the same schedule.
+		again, err := Parse(q.String(), WithDialect(DialectQuartz), WithLocation(time.UTC))
+		if err != nil {
+			t.Errorf("Parse(%q), resolved from %q: %v", q.String(), tt.quartz, err)
+		} else if got := again.NextN(from, 30); !slices.EqualFunc(got, want, time.Time.Equal) {
+			t.Errorf("%q, resolved from %q, fires at %v, want %v", q.String(), tt.quartz, got, want)
+		}
+	}
+
+	s, err := Parse("0 30 9 ? * 2 2030", WithDialect(DialectQuartz), WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("Parse with a year: %v", err)
+	}
+	if got, want := s.Next(from), time.Date(2030, 1, 7, 9, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("expected the first Monday of 2030, got %v", got)
+	}
+	if s.Expression() != "0 30 9 ? * 2 2030" || s.String() != "0 30 9 ? * 2 2030" {
+		t.Errorf("unexpected expression %q, resolved %q", s.Expression(), s.String())
+	}
+	for _, expr := range []string{"0 0 9 ? * H", "0 0 9 ? * H(2-6)", "0 0 9 ? * H/2"} {
+		q, err := Parse(expr, WithDialect(DialectQuartz), WithHashSeed("task"), WithLocation(time.UTC))
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", expr, err)
+		}
+		again, err := Parse(q.String(), WithDialect(DialectQuartz), WithLocation(time.UTC))
+		if err != nil || !slices.EqualFunc(again.NextN(from, 30), q.NextN(from, 30), time.Time.Equal) {
+			t.Errorf("%q resolved to %q, which does not parse to the same schedule: %v", expr, q.String(), err)
+		}
+	}
+	if _, err := Parse("@weekly", WithDialect(DialectQuartz)); err != nil {
+		t.Errorf("expected macros in the Quartz dialect, got %v", err)
+	}
+}
+
+// TestDialect_MacroRoundTrip tests that resolved macros parse again in every dialect
+func TestDialect_MacroRoundTrip(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	macros := []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly", "@minutely"}
+	for _, d := range []Dialect{DialectStandard, DialectAuto, DialectQuartz} {
+		for _, macro := range macros {
+			s, err := Parse(macro, WithDialect(d), WithLocation(time.UTC))
+			if err != nil {
+				t.Fatalf("%s: Parse(%q): %v", d, macro, err)
+			}
+			again, err := Parse(s.String(), WithDialect(d), WithLocation(time.UTC))
+			if err != nil {
+				t.Errorf("%s: %q resolved to %q, which does not parse: %v", d, macro, s.String(), err)
+				continue
+			}
+			if got, want := again.NextN(from, 10), s.NextN(from, 10); !slices.EqualFunc(got, want, time.Time.Equal) {
+				t.Errorf("%s: %q resolved to %q, which fires at %v, want %v", d, macro, s.String(), got, want)
+			}
+		}
+	}
+
+	for macro, want := range map[string]string{"@daily": "0 0 0 * * ?", "@weekly": "0 0 0 ? * 1", "@yearly": "0 0 0 1 1 ?"} {
+		if s, _ := Parse(macro, WithDialect(DialectQuartz)); s.String() != want {
+			t.Errorf("%q resolved to %q, want %q", macro, s.String(), want)
+		}
+	}
+}
+
+// TestDialect_QuartzInvalid tests expressions rejected by the Quartz dialect
+func TestDialect_QuartzInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"0 12 * * MON-FRI",        // no seconds
+		"0 0 12 * * MON-FRI",      // no ?
+		"0 0 12 ? * ?",            // ? in both day fields
+		"0 0 12 1 * 2",            // day of month and day of week
+		"0 0 12 ? * 0",            // Sunday is 1
+		"0 0 12 ? * 8",            // beyond Saturday
+		"0 0 12 ? * 0-3",          // range from 0
+		"0 0 12 ? * 6#6",          // sixth Friday
+		"0 0 12 ? * 2 2030 extra", // too many fields
+		"0 0 12 ? * MON-FRI 1969", // year out of range
+	} {
+		if _, err := Parse(expr, WithDialect(DialectQuartz)); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("Parse(%q): expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+
+	// The standard dialect is unchanged.
+	if _, err := Parse("0 0 12 ? * MON-FRI"); err == nil {
+		t.Error("expected the standard dialect to reject 6 fields")
+	}
+}
+
+// TestDialect_UpdateTask tests that updates keep the dialect of a task
+func TestDialect_UpdateTask(t *testing.T) {
+	s := NewScheduler()
+	job, _ := WrapJob("quartz", func() error { return nil })
+	if err := s.AddTask("0 0 9 ? * 2-6", job, WithDialect(DialectQuartz), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if err := s.UpdateTask("quartz", "0 0 10 ? * 1"); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	info, _ := s.Task("quartz")
+	if info.ResolvedExpression != "0 0 10 ? * 1" || !info.EnableSeconds {
+		t.Errorf("expected the Quartz dialect to be kept, got %q", info.ResolvedExpression)
+	}
+	if info.NextRunTime.Weekday() != time.Sunday {
+		t.Errorf("expected the next run on a Sunday, got %v", info.NextRunTime)
+	}
+}
+
+// TestParseDialect tests dialect names as used in config files
+func TestParseDialect(t *testing.T) {
+	for name, want := range map[string]Dialect{"": DialectStandard, "standard": DialectStandard, "Auto": DialectAuto, "QUARTZ": DialectQuartz} {
+		if d, err := ParseDialect(name); err != nil || d != want {
+			t.Errorf("ParseDialect(%q) = %v, %v; want %v", name, d, err, want)
+		}
+	}
+	if _, err := ParseDialect("unix"); err == nil {
+		t.Error("expected an unknown dialect to be rejected")
+	}
+
+	opts, err := TaskConfig{ID: "q", Dialect: "quartz"}.Options()
+	if err != nil {
+		t.Fatalf("Options failed: %v", err)
+	}
+	if _, err := Parse("0 0 12 ? * MON-FRI", opts...); err != nil {
+		t.Errorf("expected the config dialect to apply, got %v", err)
+	}
+	if _, err := (TaskConfig{ID: "q", Dialect: "unix"}).Options(); err == nil {
+		t.Error("expected an invalid config dialect to be rejected")
+	}
+}
@@ -0,0 +1,230 @@
+package golitecron
+
+import "time"
+
+// DSTGap selects how a fire time is handled when its wall clock time is skipped
+// because clocks spring forward, such as 02:30 on the day New York moves from
+// 02:00 to 03:00.
+type DSTGap int
+
+const (
+	// DSTGapShift runs at the skipped time moved forward by the size of the gap,
+	// e.g. 03:30 for 02:30.
+	DSTGapShift DSTGap = iota
+	// DSTGapSkip does not run for the skipped time.
+	DSTGapSkip
+	// DSTGapTransition runs at the moment clocks change, e.g. 03:00 for 02:30.
+	DSTGapTransition
+)
+
+// DSTOverlap selects how a fire time is handled when its wall clock time occurs
+// twice because clocks fall back, such as 01:30 on the day New York moves from
+// 02:00 back to 01:00.
+type DSTOverlap int
+
+const (
+	// DSTOverlapFirst runs once, at the first occurrence.
+	DSTOverlapFirst DSTOverlap = iota
+	// DSTOverlapSecond runs once, at the second occurrence.
+	DSTOverlapSecond
+	// DSTOverlapBoth runs at both occurrences.
+	DSTOverlapBoth
+)
+
+// WithDSTPolicy sets how fire times are handled when the location's clocks change.
+// The default is DSTGapShift and DSTOverlapFirst.
+func WithDSTPolicy(gap DSTGap, overlap DSTOverlap) Option {
+	return func(p *CronParser) {
+		p.dstGap = gap
+		p.dstOverlap = overlap
+	}
+}
+
+// zonePeriod is the span during which a location keeps one UTC offset, with how far
+// clocks moved at each end: positive when they sprang forward, negative when they
+// fell back, and zero at an open end.
+type zonePeriod struct {
+	start, end           time.Time
+	offset               int // seconds east of UTC
+	startShift, endShift time.Duration
+}
+
+// maxZoneShift bounds how far clocks move at a transition; the largest on record
+// is a whole day, when Samoa crossed the date line. Shifts at transitions further
+// than this from t cannot affect it and are left zero.
+const maxZoneShift = 25 * time.Hour
+
+func periodOf(t time.Time) zonePeriod {
+	z := zonePeriod{}
+	z.start, z.end = t.ZoneBounds()
+	_, z.offset = t.Zone()
+	if !z.start.IsZero() && t.Sub(z.start) < maxZoneShift {
+		_, before := z.start.Add(-time.Nanosecond).Zone()
+		z.startShift = time.Duration(z.offset-before) * time.Second
+	}
+	if !z.end.IsZero() && z.end.Sub(t) < maxZoneShift {
+		_, after := z.end.Zone()
+		z.endShift = time.Duration(after-z.offset) * time.Second
+	}
+	return z
+}
+
+// instants returns the instants at which a wall clock time fires under the DST
+// policy, in order: normally one, none for a skipped time and two for a repeated
+// time that runs twice.
+func (p *CronParser) instants(year, month, day, hour, minute, second int) ([2]time.Time, int) {
+	t := time.Date(year, time.Month(month), day, hour, minute, second, 0, p.location)
+	if t.Day() != day || t.Hour() != hour || t.Minute() != minute || t.Second() != second {
+		wall := time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC)
+		if at, ok := p.skipped(t, wall); ok {
+			return [2]time.Time{at}, 1
+		}
+		return [2]time.Time{}, 0
+	}
+
+	earlier, later, ok := repeated(t)
+	switch {
+	case !ok:
+		return [2]time.Time{t}, 1
+	case p.dstOverlap == DSTOverlapSecond:
+		return [2]time.Time{later}, 1
+	case p.dstOverlap == DSTOverlapBoth:
+		return [2]time.Time{earlier, later}, 2
+	default:
+		return [2]time.Time{earlier}, 1
+	}
+}
+
+// skipped resolves a wall time that does not exist because clocks sprang forward.
+// t is what time.Date made of it, which may lie on either side of the gap.
+func (p *CronParser) skipped(t, wall time.Time) (time.Time, bool) {
+	if p.dstGap == DSTGapSkip {
+		return time.Time{}, false
+	}
+	z := periodOf(t)
+	transition := z.start
+	if tWall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC); tWall.Before(wall) {
+		transition = z.end // normalized to before the gap
+	}
+	if transition.IsZero() {
+		return t, true
+	}
+	if p.dstGap == DSTGapTransition {
+		return transition, true
+	}
+	// Read the wall time with the offset in effect before the gap.
+	_, before := transition.Add(-time.Nanosecond).Zone()
+	return time.Unix(wall.Unix()-int64(before), 0).In(p.location), true
+}
+
+// repeated reports whether the wall time of t occurs twice because clocks fell back,
+// returning both occurrences.
+func repeated(t time.Time) (earlier, later time.Time, ok bool) {
+	z := periodOf(t)
+	if z.endShift < 0 && !t.Before(z.end.Add(z.endShift)) {
+		return t, t.Add(-z.endShift), true
+	}
+	if z.startShift < 0 && t.Before(z.start.Add(-z.startShift)) {
+		return t.Add(z.startShift), t, true
+	}
+	return t, t, false
+}
+
+// rewindNext returns an earlier wall time to also search from for fire times after
+// t. Wall clock order and time order disagree after t when t is in the first pass
+// of a repeated hour, or just after a gap whose times are shifted past t.
+func (p *CronParser) rewindNext(t time.Time) (time.Time, bool) {
+	z := periodOf(t)
+	if z.endShift < 0 && !t.Before(z.end.Add(z.endShift)) {
+		return z.end, true
+	}
+	if z.startShift > 0 && p.dstGap == DSTGapShift && t.Before(z.start.Add(z.startShift)) {
+		// The wall time at which the gap starts, read in UTC.
+		return time.Unix(z.start.Unix()+int64(z.offset)-int64(z.startShift/time.Second), 0).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// rewindPrev returns another wall time to also search from for fire times before
+// t. Wall clock order and time order disagree before t when t is in the second
+// pass of a repeated hour, or after a gap whose times are shifted to interleave
+// with the wall times following it.
+func (p *CronParser) rewindPrev(t time.Time) (time.Time, bool) {
+	z := periodOf(t)
+	if z.startShift < 0 && t.Before(z.start.Add(-z.startShift)) {
+		return z.start.Add(-time.Nanosecond), true
+	}
+	if z.startShift > 0 && p.dstGap == DSTGapShift && t.After(z.start) {
+		// The last wall time of the gap whose shifted time is before t, read in UTC.
+		before := time.Duration(z.offset)*time.Second - z.startShift
+		from := t.UTC().Add(before)
+		if gapEnd := z.start.UTC().Add(before + z.startShift); gapEnd.Before(from) {
+			from = gapEnd
+		}
+		return from.Add(-time.Nanosecond), true
+	}
+	return time.Time{}, false
+}
+
+// steadySpan caches the part of a zone period at least maxZoneShift away from its
+// transitions, where fire times follow from the wall clock alone. Zero bounds are open.
+type steadySpan struct {
+	from, until time.Time
+	set         bool
+}
+
+// covers reports whether t and u, a later time within the hour, lie in the span,
+// first moving the span to t's zone period if needed.
+func (s *steadySpan) covers(t, u time.Time) bool {
+	if !s.set || !s.contains(t) {
+		z := periodOf(t)
+		*s = steadySpan{set: true}
+		if !z.start.IsZero() {
+			s.from = z.start.Add(maxZoneShift)
+		}
+		if !z.end.IsZero() {
+			s.until = z.end.Add(-maxZoneShift)
+		}
+	}
+	return s.contains(t) && s.contains(u)
+}
+
+func (s *steadySpan) contains(t time.Time) bool {
+	return (s.from.IsZero() || !t.Before(s.from)) && (s.until.IsZero() || t.Before(s.until))
+}
+
+// gapFires reports whether t is the fire time of a wall time skipped when clocks
+// sprang forward just before t, such as 03:30 for 02:30 under DSTGapShift.
+func (p *CronParser) gapFires(t time.Time) bool {
+	if p.dstGap == DSTGapSkip {
+		return false
+	}
+	z := periodOf(t)
+	if z.startShift <= 0 || !t.Before(z.start.Add(z.startShift)) {
+		return false
+	}
+	if p.dstGap == DSTGapTransition {
+		unit := time.Minute
+		if p.enableSeconds {
+			unit = time.Second
+		}
+		return t.Sub(z.start) < unit && p.Next(z.start.Add(-time.Nanosecond)).Equal(z.start)
+	}
+	// The skipped wall time read with the offset in effect before the gap, in UTC.
+	wall := t.UTC().Add(time.Duration(z.offset)*time.Second - z.startShift)
+	return p.matchesWall(wall)
+}
+
+// overlapFires reports whether t, whose wall time matches, fires under the overlap
+// policy when that wall time occurs twice.
+func (p *CronParser) overlapFires(t time.Time) bool {
+	earlier, _, ok := repeated(t)
+	switch {
+	case !ok || p.dstOverlap == DSTOverlapBoth:
+		return true
+	case p.dstOverlap == DSTOverlapSecond:
+		return !t.Equal(earlier)
+	default:
+		return t.Equal(earlier)
+	}
+}
@@ -0,0 +1,167 @@
+package golitecron
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType identifies a scheduler event.
+type EventType int
+
+const (
+	EventTaskAdded EventType = iota
+	EventTaskRemoved
+	EventTaskUpdated
+	EventTaskPaused
+	EventTaskResumed
+	EventExecutionStarted
+	// EventExecutionFinished carries the outcome (Error) and Duration of an execution, after retries.
+	EventExecutionFinished
+	// EventRetry is sent before an attempt is retried after a failure; Attempt is the failed attempt.
+	EventRetry
+	// EventTimeout is sent when an attempt exceeds its timeout.
+	EventTimeout
+	// EventSkipped is sent when a scheduled fire is skipped because the task is still running.
+	EventSkipped
+)
+
+func (e EventType) String() string {
+	switch e {
+	case EventTaskAdded:
+		return "task_added"
+	case EventTaskRemoved:
+		return "task_removed"
+	case EventTaskUpdated:
+		return "task_updated"
+	case EventTaskPaused:
+		return "task_paused"
+	case EventTaskResumed:
+		return "task_resumed"
+	case EventExecutionStarted:
+		return "execution_started"
+	case EventExecutionFinished:
+		return "execution_finished"
+	case EventRetry:
+		return "retry"
+	case EventTimeout:
+		return "timeout"
+	case EventSkipped:
+		return "skipped"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(e))
+	}
+}
+
+// Event is a scheduler event. Fields that do not apply to the type are zero.
+type Event struct {
+	Seq         uint64 // increases by one per event published by the scheduler
+	Type        EventType
+	Time        time.Time
+	TaskID      string
+	Tags        []string
+	ExecutionID uint64
+	Scheduled   time.Time     // intended fire time; zero for triggered executions
+	Triggered   bool          // execution started by TriggerTask
+	Attempt     int           // zero-based attempt number for EventRetry and EventTimeout
+	Duration    time.Duration // for EventExecutionFinished
+	Error       string
+}
+
+// EventSubscription receives scheduler events. Events are dropped rather than
+// delaying the scheduler when its buffer is full.
+type EventSubscription struct {
+	s       *Scheduler
+	c       chan Event
+	dropped atomic.Uint64
+	once    sync.Once
+}
+
+// C returns the channel events are delivered on. It is closed by Close.
+func (sub *EventSubscription) C() <-chan Event {
+	return sub.c
+}
+
+// Dropped returns how many events were dropped because the buffer was full.
+func (sub *EventSubscription) Dropped() uint64 {
+	return sub.dropped.Load()
+}
+
+// Close stops delivery and closes the channel.
+func (sub *EventSubscription) Close() {
+	sub.once.Do(func() {
+		sub.s.eventsMu.Lock()
+		delete(sub.s.eventSubs, sub)
+		sub.s.eventsMu.Unlock()
+		close(sub.c)
+	})
+}
+
+// Events subscribes to scheduler events with a channel buffer of the given size.
+// The caller must Close the subscription when done.
+func (s *Scheduler) Events(buffer int) *EventSubscription {
+	sub := &EventSubscription{s: s, c: make(chan Event, max(buffer, 0))}
+
+	s.eventsMu.Lock()
+	if s.eventSubs == nil {
+		s.eventSubs = make(map[*EventSubscription]struct{})
+	}
+	s.eventSubs[sub] = struct{}{}
+	s.eventsMu.Unlock()
+	return sub
+}
+
+// hasEventSubscribers lets callers skip building events nobody receives.
+func (s *Scheduler) hasEventSubscribers() bool {
+	s.eventsMu.RLock()
+	defer s.eventsMu.RUnlock()
+	return len(s.eventSubs) > 0
+}
+
+// publish delivers e to all subscribers without blocking.
+func (s *Scheduler) publish(e Event) {
+	s.eventsMu.RLock()
+	defer s.eventsMu.RUnlock()
+	if len(s.eventSubs) == 0 {
+		return
+	}
+
+	e.Seq = s.eventSeq.Add(1)
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	for sub := range s.eventSubs {
+		select {
+		case sub.c <- e:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
+
+// publishFor fills in the task fields of e and publishes it.
+func (s *Scheduler) publishFor(t *Task, e Event) {
+	if !s.hasEventSubscribers() {
+		return
+	}
+	e.TaskID = t.ID
+	e.Tags = slices.Clone(t.CronParser.tags)
+	s.publish(e)
+}
+
+func (s *Scheduler) publishFinished(t *Task, rec ExecutionRecord, err error) {
+	e := Event{
+		Type:        EventExecutionFinished,
+		Time:        rec.Finished,
+		ExecutionID: rec.ID,
+		Scheduled:   rec.Scheduled,
+		Triggered:   rec.Triggered,
+		Duration:    rec.Duration(),
+	}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	s.publishFor(t, e)
+}
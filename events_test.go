@@ -0,0 +1,152 @@
+package golitecron
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// collect reads events until n have arrived or the timeout expires.
+func collect(t *testing.T, sub *EventSubscription, n int, timeout time.Duration) []Event {
+	t.Helper()
+	var events []Event
+	deadline := time.After(timeout)
+	for len(events) < n {
+		select {
+		case e := <-sub.C():
+			events = append(events, e)
+		case <-deadline:
+			return events
+		}
+	}
+	return events
+}
+
+func eventTypes(events []Event) []EventType {
+	types := make([]EventType, len(events))
+	for i, e := range events {
+		types[i] = e.Type
+	}
+	return types
+}
+
+// TestEvents_Lifecycle tests task lifecycle events with tags
+func TestEvents_Lifecycle(t *testing.T) {
+	s := NewScheduler()
+	sub := s.Events(16)
+	defer sub.Close()
+
+	job, _ := WrapJob("tagged", func() error { return nil })
+	if err := s.AddTask("0 9 * * *", job, WithTags("billing", "nightly")); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if info, _ := s.Task("tagged"); len(info.Tags) != 2 || info.Tags[1] != "nightly" {
+		t.Errorf("unexpected tags in %+v", info)
+	}
+	_ = s.PauseTask("tagged")
+	_ = s.PauseTask("tagged") // no-op, no event
+	_ = s.ResumeTask("tagged")
+	_ = s.UpdateTask("tagged", "0 10 * * *")
+	s.RemoveTaskByID("tagged")
+
+	events := collect(t, sub, 5, time.Second)
+	want := []EventType{EventTaskAdded, EventTaskPaused, EventTaskResumed, EventTaskUpdated, EventTaskRemoved}
+	if len(events) != len(want) {
+		t.Fatalf("got %v, want %v", eventTypes(events), want)
+	}
+	for i, e := range events {
+		if e.Type != want[i] || e.TaskID != "tagged" || len(e.Tags) != 2 || e.Tags[0] != "billing" || e.Time.IsZero() {
+			t.Errorf("event %d: unexpected %+v", i, e)
+		}
+		if i > 0 && e.Seq != events[i-1].Seq+1 {
+			t.Errorf("event %d: seq %d does not follow %d", i, e.Seq, events[i-1].Seq)
+		}
+	}
+}
+
+// TestEvents_Execution tests execution, retry and timeout events
+func TestEvents_Execution(t *testing.T) {
+	s := NewScheduler()
+	sub := s.Events(64)
+	defer sub.Close()
+
+	var calls int32
+	job, _ := WrapJob("flaky", func() error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return errors.New("first attempt fails")
+		}
+		return nil
+	})
+	if err := s.AddTask("0 0 1 1 *", job, WithRetry(1)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	slow, _ := WrapJob("slow", func() error {
+		time.Sleep(time.Second)
+		return nil
+	})
+	if err := s.AddTask("0 0 1 1 *", slow, WithTimeout(50*time.Millisecond)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	defer s.Stop()
+	collect(t, sub, 2, time.Second) // task_added events
+
+	_ = s.TriggerTask("flaky")
+	events := collect(t, sub, 3, 2*time.Second)
+	want := []EventType{EventExecutionStarted, EventRetry, EventExecutionFinished}
+	if len(events) != 3 {
+		t.Fatalf("got %v, want %v", eventTypes(events), want)
+	}
+	for i, e := range events {
+		if e.Type != want[i] || e.ExecutionID == 0 || e.ExecutionID != events[0].ExecutionID {
+			t.Errorf("event %d: unexpected %+v", i, e)
+		}
+	}
+	if events[1].Error != "first attempt fails" || events[1].Attempt != 0 {
+		t.Errorf("unexpected retry event %+v", events[1])
+	}
+	if finished := events[2]; finished.Error != "" || !finished.Triggered || finished.Duration <= 0 {
+		t.Errorf("unexpected finished event %+v", finished)
+	}
+
+	_ = s.TriggerTask("slow")
+	events = collect(t, sub, 3, 2*time.Second)
+	want = []EventType{EventExecutionStarted, EventTimeout, EventExecutionFinished}
+	if len(events) != 3 || events[1].Type != EventTimeout || events[2].Error == "" {
+		t.Fatalf("got %v, want %v", eventTypes(events), want)
+	}
+}
+
+// TestEvents_DropsForSlowSubscribers tests that a full buffer drops events instead of blocking
+func TestEvents_DropsForSlowSubscribers(t *testing.T) {
+	s := NewScheduler()
+	sub := s.Events(2)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			job, _ := WrapJob(string(rune('a'+i)), func() error { return nil })
+			_ = s.AddTask("0 9 * * *", job)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publishing blocked on a slow subscriber")
+	}
+
+	if sub.Dropped() != 3 || len(sub.C()) != 2 {
+		t.Errorf("expected 2 buffered and 3 dropped events, got %d and %d", len(sub.C()), sub.Dropped())
+	}
+
+	sub.Close()
+	sub.Close()
+	if _, ok := <-sub.C(); !ok {
+		t.Error("expected buffered events to remain readable after Close")
+	}
+	job, _ := WrapJob("after-close", func() error { return nil })
+	_ = s.AddTask("0 9 * * *", job) // must not panic
+}
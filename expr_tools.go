@@ -0,0 +1,128 @@
+package golitecron
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxDiffFireTimes bounds how many fire times DiffFireTimes enumerates per expression.
+const maxDiffFireTimes = 100000
+
+// Validate reports whether expr is a valid, satisfiable cron expression under opts.
+// Errors wrap ErrInvalidExpression and name the offending field.
+func Validate(expr string, opts ...Option) error {
+	p, err := newCronParser(expr, opts...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+	}
+	if p.Next(time.Now().In(p.location)).IsZero() {
+		return fmt.Errorf("%w: expression never fires", ErrInvalidExpression)
+	}
+	return nil
+}
+
+// ValidateConfig checks every task of config: required fields, unique IDs, options
+// and expressions. It does not check that FuncName is registered. All problems are
+// reported, joined with errors.Join.
+func ValidateConfig(config *Config) error {
+	var errs []error
+	seen := make(map[string]bool, len(config.Tasks))
+	for i, tc := range config.Tasks {
+		name := fmt.Sprintf("task %d", i)
+		if tc.ID != "" {
+			name = fmt.Sprintf("task %d (%s)", i, tc.ID)
+		}
+
+		switch {
+		case tc.ID == "":
+			errs = append(errs, fmt.Errorf("%s: id is required", name))
+		case seen[tc.ID]:
+			errs = append(errs, fmt.Errorf("%s: duplicate id", name))
+		}
+		seen[tc.ID] = true
+		if tc.FuncName == "" && tc.Command == "" {
+			errs = append(errs, fmt.Errorf("%s: func_name or command is required", name))
+		}
+		if tc.CronExpr == "" {
+			errs = append(errs, fmt.Errorf("%s: cron_expr is required", name))
+			continue
+		}
+
+		opts, err := tc.Options()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if err := Validate(tc.CronExpr, opts...); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Explain describes expr in plain English, e.g. "At 09:30, on Monday through Friday".
+func Explain(expr string, opts ...Option) (string, error) {
+	p, err := newCronParser(expr, opts...)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+	}
+	return p.describe(), nil
+}
+
+// FireTimes returns the next n fire times of expr after from, in the location set by
+// opts (time.Local by default). Fewer are returned if the schedule ends.
+func FireTimes(expr string, from time.Time, n int, opts ...Option) ([]time.Time, error) {
+	p, err := newCronParser(expr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+	}
+
+	return p.NextN(from, n), nil
+}
+
+// DiffFireTimes compares the fire times of two expressions in [from, to) and returns
+// those only a fires at and those only b fires at. Both use the same opts.
+func DiffFireTimes(a, b string, from, to time.Time, opts ...Option) (onlyA, onlyB []time.Time, err error) {
+	timesA, err := fireTimesBetween(a, from, to, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	timesB, err := fireTimesBetween(b, from, to, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Both lists are sorted; merge them.
+	i, j := 0, 0
+	for i < len(timesA) || j < len(timesB) {
+		switch {
+		case j == len(timesB) || i < len(timesA) && timesA[i].Before(timesB[j]):
+			onlyA = append(onlyA, timesA[i])
+			i++
+		case i == len(timesA) || timesB[j].Before(timesA[i]):
+			onlyB = append(onlyB, timesB[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return onlyA, onlyB, nil
+}
+
+func fireTimesBetween(expr string, from, to time.Time, opts ...Option) ([]time.Time, error) {
+	p, err := newCronParser(expr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrInvalidExpression, expr, err)
+	}
+
+	var times []time.Time
+	for next := range p.Between(from, to) {
+		if len(times) == maxDiffFireTimes {
+			return nil, fmt.Errorf("%q fires more than %d times in the horizon; shorten it", expr, maxDiffFireTimes)
+		}
+		times = append(times, next)
+	}
+	return times, nil
+}
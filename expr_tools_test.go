@@ -0,0 +1,117 @@
+package golitecron
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestValidate_Errors tests that validation errors name the offending field
+func TestValidate_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		opts []Option
+		want string
+	}{
+		{"* * * *", nil, "expected 5 fields (minute hour day-of-month month day-of-week), got 4"},
+		{"61 * * * *", nil, `minute field "61" (allowed 0-59)`},
+		{"0 0 32 * *", nil, `day-of-month field "32" (allowed 1-31)`},
+		{"0 0 * * * 10000", []Option{WithYears()}, `year field "10000"`},
+		{"@fortnightly", nil, "unknown macro @fortnightly"},
+		{"0 0 1 1 * 2020", []Option{WithYears()}, "never fires"},
+	}
+	for _, tt := range tests {
+		err := Validate(tt.expr, tt.opts...)
+		if !errors.Is(err, ErrInvalidExpression) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.expr, tt.want, err)
+		}
+	}
+	if err := Validate("*/5 9-17 * * 1-5"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestValidateConfig tests that all problems of a config are reported
+func TestValidateConfig(t *testing.T) {
+	config := &Config{Tasks: []TaskConfig{
+		{ID: "ok", CronExpr: "0 9 * * *", FuncName: "f"},
+		{ID: "ok", CronExpr: "0 9 * * *", Command: "true"},
+		{CronExpr: "0 9 * * *", FuncName: "f"},
+		{ID: "bad-expr", CronExpr: "0 25 * * *", FuncName: "f"},
+		{ID: "bad-timeout", CronExpr: "0 9 * * *", FuncName: "f", Timeout: "later"},
+		{ID: "no-job", CronExpr: "0 9 * * *"},
+	}}
+	err := ValidateConfig(config)
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	for _, want := range []string{
+		"task 1 (ok): duplicate id",
+		"task 2: id is required",
+		`task 3 (bad-expr): invalid cron expression: error parsing hour field "25"`,
+		"task 4 (bad-timeout): invalid timeout",
+		"task 5 (no-job): func_name or command is required",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "task 0") {
+		t.Errorf("valid task reported:\n%v", err)
+	}
+}
+
+// TestFireTimes tests listing upcoming fire times in a location
+func TestFireTimes(t *testing.T) {
+	from := time.Date(2026, 3, 6, 12, 0, 0, 0, time.UTC) // Friday
+	times, err := FireTimes("30 9 * * 1-5", from, 3, WithLocation(time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []time.Time{
+		time.Date(2026, 3, 9, 9, 30, 0, 0, time.UTC),
+		time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC),
+		time.Date(2026, 3, 11, 9, 30, 0, 0, time.UTC),
+	}
+	if len(times) != len(want) {
+		t.Fatalf("got %v, want %v", times, want)
+	}
+	for i := range want {
+		if !times[i].Equal(want[i]) {
+			t.Errorf("time %d: got %v, want %v", i, times[i], want[i])
+		}
+	}
+
+	if _, err := FireTimes("bad", from, 3); !errors.Is(err, ErrInvalidExpression) {
+		t.Errorf("expected ErrInvalidExpression, got %v", err)
+	}
+}
+
+// TestDiffFireTimes tests comparing two expressions over a horizon
+func TestDiffFireTimes(t *testing.T) {
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC) // Monday
+	to := from.AddDate(0, 0, 7)
+
+	onlyA, onlyB, err := DiffFireTimes("0 9 * * 1-5", "0 9 * * 1,3,5,6", from, to, WithLocation(time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Tuesday and Thursday are dropped, Saturday is added.
+	if len(onlyA) != 2 || onlyA[0].Weekday() != time.Tuesday || onlyA[1].Weekday() != time.Thursday {
+		t.Errorf("unexpected onlyA %v", onlyA)
+	}
+	if len(onlyB) != 1 || onlyB[0].Weekday() != time.Saturday {
+		t.Errorf("unexpected onlyB %v", onlyB)
+	}
+
+	// A fire exactly at from is included.
+	onlyA, _, _ = DiffFireTimes("0 0 * * *", "0 12 * * *", from, from.Add(time.Hour), WithLocation(time.UTC))
+	if len(onlyA) != 1 || !onlyA[0].Equal(from) {
+		t.Errorf("expected fire at from, got %v", onlyA)
+	}
+
+	if _, _, err := DiffFireTimes("* * * * * *", "0 * * * * *", from, from.AddDate(1, 0, 0), WithSeconds()); err == nil {
+		t.Error("expected error for too many fire times")
+	}
+}
@@ -0,0 +1,100 @@
+package golitecron
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+)
+
+// WithHashSeed sets the seed H tokens are resolved from. Tasks default to their ID,
+// also when added with AddSchedule; set it explicitly to keep offsets when renaming
+// a task. Schedule methods such as Next resolve H with an empty seed otherwise.
+func WithHashSeed(seed string) Option {
+	return func(p *CronParser) {
+		p.hashSeed = seed
+	}
+}
+
+// hashValue returns a stable pseudo-random number for a seed, field and item of a
+// list, so that the fields of one task, and the H items of one field, get
+// independent offsets. Item i > 0 of a list also hashes i; item 0 hashes only the
+// seed and field.
+func hashValue(seed string, field FieldType, item int) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	h.Write([]byte{0, byte(field)})
+	if item > 0 {
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(item)))
+	}
+	return h.Sum64()
+}
+
+// resolveHash replaces the H tokens of a field with concrete values derived from
+// seed, e.g. "H/15" becomes "7-59/15". H is one value of the field's range (1-28
+// for day of month, so that it occurs every month), H(a-b) one value of a-b, and
+// H/n or H(a-b)/n every n values from an offset below n. Years only accept H(a-b)
+// and H(a-b)/n. Each H item of a list is hashed separately.
+func resolveHash(field string, rule parseRule, seed string) (string, error) {
+	if !strings.Contains(field, "H") {
+		return field, nil
+	}
+	items := strings.Split(field, ",")
+	for i, item := range items {
+		if !strings.HasPrefix(item, "H") {
+			continue // e.g. THU
+		}
+		resolved, err := resolveHashItem(item, rule, hashValue(seed, rule.field, i))
+		if err != nil {
+			return "", err
+		}
+		items[i] = resolved
+	}
+	return strings.Join(items, ","), nil
+}
+
+func resolveHashItem(item string, rule parseRule, hash uint64) (string, error) {
+	lo, hi := rule.min, rule.max
+	if rule.field == DayOfMonth {
+		hi = 28
+	}
+
+	rest := item[1:]
+	if r, ok := strings.CutPrefix(rest, "("); ok {
+		rng, after, ok := strings.Cut(r, ")")
+		if !ok {
+			return "", fmt.Errorf("invalid 'H' format: %s", item)
+		}
+		startStr, endStr, ok := strings.Cut(rng, "-")
+		if !ok {
+			return "", fmt.Errorf("invalid 'H' range: %s", item)
+		}
+		start, err := parseValue(startStr, rule.min, rule.max, rule.field)
+		if err != nil {
+			return "", fmt.Errorf("invalid 'H' range start: %s", startStr)
+		}
+		end, err := parseValue(endStr, rule.min, rule.max, rule.field)
+		if err != nil || end < start {
+			return "", fmt.Errorf("invalid 'H' range end: %s", endStr)
+		}
+		lo, hi, rest = start, end, after
+	} else if rule.field == Years {
+		// Any year of 1970-9999 would likely be far off or already past.
+		return "", fmt.Errorf("'H' in Years needs a range, e.g. H(2025-2030): %s", item)
+	}
+
+	switch {
+	case rest == "":
+		return strconv.Itoa(lo + int(hash%uint64(hi-lo+1))), nil
+	case strings.HasPrefix(rest, "/"):
+		step, err := strconv.Atoi(rest[1:])
+		if err != nil || step <= 0 {
+			return "", fmt.Errorf("invalid step value: %s", rest[1:])
+		}
+		start := lo + int(hash%uint64(min(step, hi-lo+1)))
+		return fmt.Sprintf("%d-%d/%d", start, hi, step), nil
+	default:
+		return "", fmt.Errorf("invalid 'H' format: %s", item)
+	}
+}
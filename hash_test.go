@@ -0,0 +1,158 @@
+package golitecron
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestResolveHash(t *testing.T) {
+	tests := []struct {
+		expr    string
+		opts    []Option
+		pattern string
+	}{
+		{"H * * * *", nil, `^\d+ \* \* \* \*$`},
+		{"H(0-29) H(9-17) * * *", nil, `^\d+ \d+ \* \* \*$`},
+		{"H/15 * * * *", nil, `^\d+-59/15 \* \* \* \*$`},
+		{"H(0-29)/10 * * * *", nil, `^\d+-29/10 \* \* \* \*$`},
+		{"0 0 H * *", nil, `^0 0 \d+ \* \*$`},
+		{"0 0 * * H(MON-FRI)", nil, `^0 0 \* \* [1-5]$`},
+		{"0 0 * * H,THU", nil, `^0 0 \* \* \d,THU$`},
+		{"H H * * * *", []Option{WithSeconds()}, `^\d+ \d+ \* \* \* \*$`},
+		{"0 0 0 1 1 * H(2030-2035)", []Option{WithSeconds(), WithYears()}, `^0 0 0 1 1 \* 203[0-5]$`},
+		{"0 0 0 1 1 * H(2030-2039)/5", []Option{WithSeconds(), WithYears()}, `^0 0 0 1 1 \* 203[0-4]-2039/5$`},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 50; i++ {
+			seed := fmt.Sprintf("task-%d", i)
+			p, err := newCronParser(tt.expr, append([]Option{WithHashSeed(seed)}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("%q: unexpected error: %v", tt.expr, err)
+			}
+			resolved := p.resolved()
+			if !regexp.MustCompile(tt.pattern).MatchString(resolved) {
+				t.Fatalf("%q resolved to %q", tt.expr, resolved)
+			}
+			if again, _ := newCronParser(tt.expr, append([]Option{WithHashSeed(seed)}, tt.opts...)...); again.resolved() != resolved {
+				t.Fatalf("%q: resolution is not stable", tt.expr)
+			}
+		}
+	}
+
+	// Values stay within the given ranges.
+	for i := 0; i < 200; i++ {
+		p, err := newCronParser("H(0-29)/10 H(9-17) H * *", WithHashSeed(strconv.Itoa(i)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if first := p.minutes.values()[0]; first > 9 || p.minutes.count() != 3 {
+			t.Fatalf("unexpected minutes %v", p.minutes.values())
+		}
+		if h := p.hours.values()[0]; h < 9 || h > 17 {
+			t.Fatalf("hour %d outside 9-17", h)
+		}
+		if d := p.dayOfMonth.values()[0]; d < 1 || d > 28 {
+			t.Fatalf("day %d outside 1-28", d)
+		}
+	}
+
+	for _, expr := range []string{"H(30-10) * * * *", "H(0-60) * * * *", "H/0 * * * *", "Hx * * * *", "H(0-5 * * * *", "H-5 * * * *"} {
+		if _, err := newCronParser(expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+
+	// Years need an explicit range.
+	for _, expr := range []string{"0 0 1 1 * H", "0 0 1 1 * H/2", "0 0 1 1 * 2030,H"} {
+		if _, err := newCronParser(expr, WithYears()); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}
+
+// TestResolveHash_ListItems tests that the H items of one field are hashed independently
+func TestResolveHash_ListItems(t *testing.T) {
+	const seeds = 200
+	var pairs, shifted int
+	for i := 0; i < seeds; i++ {
+		seed := WithHashSeed(fmt.Sprintf("task-%d", i))
+		p, err := newCronParser("H,H * * * *", seed)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if p.minutes.count() == 2 {
+			pairs++
+		}
+		p, err = newCronParser("H(0-29),H(30-59) * * * *", seed)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if m := p.minutes.values(); m[1]-m[0] != 30 {
+			shifted++
+		}
+	}
+	// Independent items coincide for about 1 seed in 60, or in 30.
+	if pairs < seeds*9/10 {
+		t.Errorf("H,H resolved to two minutes for only %d of %d seeds", pairs, seeds)
+	}
+	if shifted < seeds*9/10 {
+		t.Errorf("H(0-29),H(30-59) were 30 minutes apart for %d of %d seeds", seeds-shifted, seeds)
+	}
+}
+
+func TestResolveHash_Spread(t *testing.T) {
+	counts := make(map[int]int)
+	const tasks = 600
+	for i := 0; i < tasks; i++ {
+		p, err := newCronParser("H * * * *", WithHashSeed(fmt.Sprintf("job-%d", i)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		counts[p.minutes.values()[0]]++
+	}
+	// 10 tasks per minute on average; no minute should attract a crowd.
+	if len(counts) < 55 {
+		t.Errorf("only %d distinct minutes for %d tasks", len(counts), tasks)
+	}
+	for minute, n := range counts {
+		if n > 30 {
+			t.Errorf("%d tasks at minute %d", n, minute)
+		}
+	}
+}
+
+func TestScheduler_HashSeededByTaskID(t *testing.T) {
+	s := NewScheduler()
+	resolved := make(map[string]string)
+	for _, id := range []string{"alpha", "beta", "gamma"} {
+		job, _ := WrapJob(id, func() error { return nil })
+		if err := s.AddTask("H H * * *", job); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+		info, _ := s.Task(id)
+		want, _ := newCronParser("H H * * *", WithHashSeed(id))
+		if info.Expression != "H H * * *" || info.ResolvedExpression != want.resolved() {
+			t.Errorf("%s: unexpected expressions %q / %q", id, info.Expression, info.ResolvedExpression)
+		}
+		resolved[id] = info.ResolvedExpression
+	}
+
+	// An explicit seed wins over the task ID, and updates keep the seed.
+	job, _ := WrapJob("renamed", func() error { return nil })
+	if err := s.AddTask("H H * * *", job, WithHashSeed("alpha")); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if info, _ := s.Task("renamed"); info.ResolvedExpression != resolved["alpha"] {
+		t.Errorf("expected the alpha offsets, got %q", info.ResolvedExpression)
+	}
+	if err := s.UpdateTask("renamed", "H H * * 1-5"); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	want := strings.TrimSuffix(resolved["alpha"], "*") + "1-5"
+	if info, _ := s.Task("renamed"); info.ResolvedExpression != want {
+		t.Errorf("expected %q after update, got %q", want, info.ResolvedExpression)
+	}
+}
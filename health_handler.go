@@ -0,0 +1,125 @@
+package golitecron
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// HealthHandlerConfig configures the status codes returned by HealthHandler.
+// Zero status codes default to http.StatusServiceUnavailable.
+type HealthHandlerConfig struct {
+	// NotRunningStatus is returned when the scheduler is not started.
+	NotRunningStatus int
+	// StalledStatus is returned when the dispatch loop has not completed a tick
+	// within the stall threshold (see WatchdogConfig.StallThreshold).
+	StalledStatus int
+
+	// FailureThreshold is the number of consecutive failures after which a task
+	// counts as failing. Zero disables failure checks, e.g. for a liveness probe.
+	FailureThreshold uint64
+	// MaxFailingTasks is the number of failing tasks tolerated before FailureStatus is returned.
+	MaxFailingTasks int
+	FailureStatus   int
+}
+
+type healthResponse struct {
+	Status       string            `json:"status"`
+	Running      bool              `json:"running"`
+	LastTick     *time.Time        `json:"last_tick,omitempty"`
+	LoopStalled  bool              `json:"loop_stalled"`
+	Storage      string            `json:"storage"`
+	Tasks        int               `json:"tasks"`
+	InFlight     int               `json:"in_flight"`
+	StuckTasks   []stuckTaskJSON   `json:"stuck_tasks"`
+	FailingTasks []failingTaskJSON `json:"failing_tasks"`
+}
+
+type stuckTaskJSON struct {
+	TaskID      string  `json:"task_id"`
+	ExecutionID uint64  `json:"execution_id"`
+	Started     string  `json:"started"`
+	RunningFor  float64 `json:"running_for_seconds"`
+	Expected    float64 `json:"expected_seconds"`
+}
+
+type failingTaskJSON struct {
+	TaskID              string `json:"task_id"`
+	ConsecutiveFailures uint64 `json:"consecutive_failures"`
+	LastError           string `json:"last_error,omitempty"`
+}
+
+// HealthHandler returns an http.Handler reporting Health() as JSON. It responds
+// 200 when healthy and the configured status otherwise, so separately configured
+// handlers can serve as liveness and readiness probes.
+func (s *Scheduler) HealthHandler(cfg HealthHandlerConfig) http.Handler {
+	if cfg.NotRunningStatus == 0 {
+		cfg.NotRunningStatus = http.StatusServiceUnavailable
+	}
+	if cfg.StalledStatus == 0 {
+		cfg.StalledStatus = http.StatusServiceUnavailable
+	}
+	if cfg.FailureStatus == 0 {
+		cfg.FailureStatus = http.StatusServiceUnavailable
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		report := s.Health()
+		resp := healthResponse{
+			Status:       "ok",
+			Running:      report.Running,
+			LoopStalled:  report.LoopStalled,
+			Storage:      report.StorageType.String(),
+			Tasks:        report.TaskCount,
+			InFlight:     report.InFlight,
+			StuckTasks:   make([]stuckTaskJSON, 0, len(report.StuckTasks)),
+			FailingTasks: make([]failingTaskJSON, 0, len(report.FailingTasks)),
+		}
+		if !report.LastTick.IsZero() {
+			resp.LastTick = &report.LastTick
+		}
+		for _, st := range report.StuckTasks {
+			resp.StuckTasks = append(resp.StuckTasks, stuckTaskJSON{
+				TaskID:      st.TaskID,
+				ExecutionID: st.ExecutionID,
+				Started:     st.Started.Format(time.RFC3339),
+				RunningFor:  st.RunningFor.Seconds(),
+				Expected:    st.Expected.Seconds(),
+			})
+		}
+
+		failing := 0
+		for _, ft := range report.FailingTasks {
+			resp.FailingTasks = append(resp.FailingTasks, failingTaskJSON(ft))
+			if cfg.FailureThreshold > 0 && ft.ConsecutiveFailures >= cfg.FailureThreshold {
+				failing++
+			}
+		}
+
+		code := http.StatusOK
+		switch {
+		case !report.Running:
+			resp.Status, code = "stopped", cfg.NotRunningStatus
+		case report.LoopStalled:
+			resp.Status, code = "stalled", cfg.StalledStatus
+		case cfg.FailureThreshold > 0 && failing > cfg.MaxFailingTasks:
+			resp.Status, code = "failing", cfg.FailureStatus
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		if r.Method == http.MethodHead {
+			return
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			s.logger.Printf("Failed to write health response: %v\n", err)
+		}
+	})
+}
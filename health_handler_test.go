@@ -0,0 +1,86 @@
+package golitecron
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func getHealth(t *testing.T, h http.Handler) (int, healthResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	var resp healthResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, resp
+}
+
+// TestHealthHandler_RunningAndStopped tests the status reported across the scheduler lifecycle
+func TestHealthHandler_RunningAndStopped(t *testing.T) {
+	s := NewScheduler(StorageTypeTimeWheel)
+	job, _ := WrapJob("health-ok", func() error { return nil })
+	if err := s.AddTask("0 0 1 1 *", job, WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	h := s.HealthHandler(HealthHandlerConfig{NotRunningStatus: http.StatusTeapot})
+
+	code, resp := getHealth(t, h)
+	if code != http.StatusTeapot || resp.Status != "stopped" || resp.Running {
+		t.Errorf("expected configured status for stopped scheduler, got %d %+v", code, resp)
+	}
+
+	s.Start()
+	defer s.Stop()
+
+	code, resp = getHealth(t, h)
+	if code != http.StatusOK || resp.Status != "ok" {
+		t.Fatalf("expected 200 ok, got %d %+v", code, resp)
+	}
+	if resp.Tasks != 1 || resp.Storage != "timewheel" || resp.LastTick == nil {
+		t.Errorf("unexpected report %+v", resp)
+	}
+}
+
+// TestHealthHandler_FailureThreshold tests readiness failing once tasks keep failing
+func TestHealthHandler_FailureThreshold(t *testing.T) {
+	s := NewScheduler()
+	job, _ := WrapJob("health-fail", func() error { return errors.New("boom") })
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	liveness := s.HealthHandler(HealthHandlerConfig{})
+	readiness := s.HealthHandler(HealthHandlerConfig{FailureThreshold: 2, FailureStatus: http.StatusInternalServerError})
+
+	s.Start()
+	time.Sleep(2500 * time.Millisecond)
+	defer s.Stop()
+
+	code, resp := getHealth(t, readiness)
+	if code != http.StatusInternalServerError || resp.Status != "failing" {
+		t.Fatalf("expected readiness failure, got %d %+v", code, resp)
+	}
+	if len(resp.FailingTasks) != 1 || resp.FailingTasks[0].TaskID != "health-fail" ||
+		resp.FailingTasks[0].ConsecutiveFailures < 2 || resp.FailingTasks[0].LastError != "boom" {
+		t.Errorf("unexpected failing tasks %+v", resp.FailingTasks)
+	}
+
+	if code, _ := getHealth(t, liveness); code != http.StatusOK {
+		t.Errorf("liveness should ignore task failures, got %d", code)
+	}
+}
+
+// TestHealthHandler_MethodNotAllowed tests that only GET and HEAD are served
+func TestHealthHandler_MethodNotAllowed(t *testing.T) {
+	s := NewScheduler()
+	rec := httptest.NewRecorder()
+	s.HealthHandler(HealthHandlerConfig{}).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/healthz", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,68 @@
+package golitecron
+
+import (
+	"iter"
+	"time"
+)
+
+// Occurrences returns the fire times after from, in order. The sequence only ends
+// when the schedule has no further fire time, so callers usually stop it with break
+// or use Between or NextN instead.
+func (p *CronParser) Occurrences(from time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		var span steadySpan
+		for next := p.Next(from); !next.IsZero(); next = p.following(next, &span) {
+			if !yield(next) {
+				return
+			}
+		}
+	}
+}
+
+// Between returns the fire times in [start, end), in order.
+func (p *CronParser) Between(start, end time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		// Start just before start so that a fire exactly at start is included.
+		for next := range p.Occurrences(start.Add(-time.Nanosecond)) {
+			if !next.Before(end) || !yield(next) {
+				return
+			}
+		}
+	}
+}
+
+// NextN returns the next n fire times after t. Fewer are returned if the schedule ends.
+func (p *CronParser) NextN(t time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, max(n, 0))
+	if n <= 0 {
+		return times
+	}
+	for next := range p.Occurrences(t) {
+		times = append(times, next)
+		if len(times) == n {
+			break
+		}
+	}
+	return times
+}
+
+// following returns the fire time after prev, a fire time returned by Next. Within
+// the same minute (or hour, without seconds) the next value is found directly,
+// without the full field-by-field search of Next, unless a DST transition is near.
+// span carries the zone period between calls.
+func (p *CronParser) following(prev time.Time, span *steadySpan) time.Time {
+	if p.enableSeconds {
+		if s, ok := p.seconds.next(prev.Second() + 1); ok {
+			next := prev.Add(time.Duration(s-prev.Second()) * time.Second)
+			if next.Second() == s && next.Minute() == prev.Minute() && span.covers(prev, next) {
+				return next
+			}
+		}
+	} else if mi, ok := p.minutes.next(prev.Minute() + 1); ok {
+		next := prev.Add(time.Duration(mi-prev.Minute()) * time.Minute)
+		if next.Minute() == mi && next.Hour() == prev.Hour() && span.covers(prev, next) {
+			return next
+		}
+	}
+	return p.Next(prev)
+}
@@ -0,0 +1,88 @@
+package golitecron
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestOccurrences_MatchesNext(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Skipf("Kolkata timezone not available: %v", err)
+	}
+	exprs := []struct {
+		expr string
+		opts []Option
+	}{
+		{"*/7 9-17 * * 1-5", nil},
+		{"0,30 * L * *", nil},
+		{"*/15 */10 * * * *", []Option{WithSeconds()}},
+	}
+	from := time.Date(2025, 12, 30, 8, 0, 0, 0, time.UTC)
+	for _, e := range exprs {
+		p, err := newCronParser(e.expr, append([]Option{WithLocation(kolkata)}, e.opts...)...)
+		if err != nil {
+			t.Fatalf("failed to create parser for %q: %v", e.expr, err)
+		}
+
+		var got []time.Time
+		for next := range p.Occurrences(from) {
+			if len(got) == 500 {
+				break
+			}
+			got = append(got, next)
+		}
+		var want []time.Time
+		for next := p.Next(from); len(want) < 500; next = p.Next(next) {
+			want = append(want, next)
+		}
+		if !slices.EqualFunc(got, want, time.Time.Equal) {
+			t.Errorf("%q: Occurrences differs from repeated Next", e.expr)
+		}
+		if n := p.NextN(from, 500); !slices.EqualFunc(n, want, time.Time.Equal) {
+			t.Errorf("%q: NextN differs from repeated Next", e.expr)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	p, err := newCronParser("0 * * * *", WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var got []time.Time
+	for next := range p.Between(start, start.Add(3*time.Hour)) {
+		got = append(got, next)
+	}
+	want := []time.Time{start, start.Add(time.Hour), start.Add(2 * time.Hour)}
+	if !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("Between = %v, want %v (start inclusive, end exclusive)", got, want)
+	}
+
+	// A year of minutely fires.
+	p, _ = newCronParser("* * * * *", WithLocation(time.UTC))
+	count := 0
+	for range p.Between(start, start.AddDate(1, 0, 0)) {
+		count++
+	}
+	if count != 365*24*60 {
+		t.Errorf("expected %d minutely fires in 2026, got %d", 365*24*60, count)
+	}
+}
+
+func TestNextN(t *testing.T) {
+	p, err := newCronParser("0 0 1 1 * 2026-2027", WithYears(), WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	if got := p.NextN(from, 5); len(got) != 2 || got[1].Year() != 2027 {
+		t.Errorf("expected the two remaining fire times, got %v", got)
+	}
+	if got := p.NextN(from, 0); len(got) != 0 {
+		t.Errorf("expected no fire times, got %v", got)
+	}
+}
@@ -0,0 +1,68 @@
+package golitecron
+
+import (
+	"context"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+)
+
+// ProfilingConfig controls per-execution profiling annotations. Both are off by default.
+type ProfilingConfig struct {
+	// Labels runs each execution under pprof.Do with "task_id" and "execution_id"
+	// labels, so CPU and goroutine profiles can be filtered with -tagfocus.
+	Labels bool
+	// Trace wraps each execution in a runtime/trace task and each attempt in a
+	// region, so `go tool trace` can break down latency by task.
+	Trace bool
+}
+
+// WithProfiling enables profiling annotations. Must be called before Start().
+func (s *Scheduler) WithProfiling(cfg ProfilingConfig) {
+	s.profiling = cfg
+}
+
+// execute runs all attempts of an execution, annotated as configured.
+// Goroutines started by the job inherit the pprof labels.
+func (s *Scheduler) execute(t *Task, execID uint64) error {
+	ctx := context.Background()
+	if !s.profiling.Labels && !s.profiling.Trace {
+		return s.attempt(ctx, t, execID)
+	}
+
+	execIDStr := strconv.FormatUint(execID, 10)
+	if s.profiling.Trace {
+		var task *trace.Task
+		ctx, task = trace.NewTask(ctx, "golitecron.execution")
+		defer task.End()
+		trace.Log(ctx, "task_id", t.ID)
+		trace.Log(ctx, "execution_id", execIDStr)
+	}
+
+	if !s.profiling.Labels {
+		return s.attempt(ctx, t, execID)
+	}
+
+	var err error
+	pprof.Do(ctx, pprof.Labels("task_id", t.ID, "execution_id", execIDStr), func(ctx context.Context) {
+		err = s.attempt(ctx, t, execID)
+	})
+	return err
+}
+
+type region interface {
+	End()
+}
+
+type noopRegion struct{}
+
+func (noopRegion) End() {}
+
+// startAttemptRegion starts a trace region for the i-th attempt when tracing is enabled.
+func (s *Scheduler) startAttemptRegion(ctx context.Context, i int) region {
+	if !s.profiling.Trace {
+		return noopRegion{}
+	}
+	trace.Log(ctx, "attempt", strconv.Itoa(i))
+	return trace.StartRegion(ctx, "golitecron.attempt")
+}
@@ -0,0 +1,103 @@
+package golitecron
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"runtime/trace"
+	"testing"
+	"time"
+)
+
+// labelJob reports the pprof labels visible to Execute.
+func labelJob(id string, labels chan<- [2]string) Job {
+	job, _ := WrapJob(id, func(ctx context.Context) error {
+		taskID, _ := pprof.Label(ctx, "task_id")
+		execID, _ := pprof.Label(ctx, "execution_id")
+		select {
+		case labels <- [2]string{taskID, execID}:
+		default:
+		}
+		return nil
+	})
+	return job
+}
+
+// TestProfiling_Labels tests that executions carry task_id and execution_id labels, also under a timeout
+func TestProfiling_Labels(t *testing.T) {
+	for _, timeout := range []time.Duration{0, time.Second} {
+		s := NewScheduler()
+		s.WithProfiling(ProfilingConfig{Labels: true})
+
+		labels := make(chan [2]string, 1)
+		if err := s.AddTask("* * * * * *", labelJob("labeled", labels), WithSeconds(),
+			WithLocation(time.UTC), WithTimeout(timeout)); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+
+		s.Start()
+		select {
+		case got := <-labels:
+			if got[0] != "labeled" || got[1] == "" {
+				t.Errorf("timeout %s: unexpected labels %v", timeout, got)
+			}
+		case <-time.After(3 * time.Second):
+			t.Fatalf("timeout %s: task did not run", timeout)
+		}
+		s.Stop()
+	}
+}
+
+// TestProfiling_DisabledByDefault tests that no labels are set unless enabled
+func TestProfiling_DisabledByDefault(t *testing.T) {
+	s := NewScheduler()
+	labels := make(chan [2]string, 1)
+	if err := s.AddTask("* * * * * *", labelJob("unlabeled", labels), WithSeconds(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	defer s.Stop()
+	select {
+	case got := <-labels:
+		if got[0] != "" || got[1] != "" {
+			t.Errorf("expected no labels, got %v", got)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("task did not run")
+	}
+}
+
+// TestProfiling_Trace tests that executions emit trace tasks while a trace is recorded
+func TestProfiling_Trace(t *testing.T) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("tracing unavailable: %v", err)
+	}
+	defer trace.Stop()
+
+	s := NewScheduler()
+	s.WithProfiling(ProfilingConfig{Labels: true, Trace: true})
+
+	labels := make(chan [2]string, 1)
+	if err := s.AddTask("* * * * * *", labelJob("traced", labels), WithSeconds(),
+		WithLocation(time.UTC), WithRetry(1)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	select {
+	case got := <-labels:
+		if got[0] != "traced" {
+			t.Errorf("unexpected labels %v", got)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("task did not run")
+	}
+	s.Stop()
+	trace.Stop()
+
+	if !bytes.Contains(buf.Bytes(), []byte("golitecron.execution")) {
+		t.Error("expected trace to contain the execution task")
+	}
+}
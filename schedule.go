@@ -0,0 +1,107 @@
+package golitecron
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+)
+
+// Schedule is a parsed cron expression. It is immutable and safe for concurrent use,
+// and can be added to any number of schedulers with AddSchedule.
+type Schedule struct {
+	parser *CronParser
+}
+
+// Parse parses a cron expression with the same syntax and options as AddTask.
+// Errors wrap ErrInvalidExpression. Parse does not check that the expression ever
+// fires; Next returns the zero time for schedules that do not.
+func Parse(expr string, opts ...Option) (*Schedule, error) {
+	p, err := newCronParser(expr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+	}
+	return &Schedule{parser: p}, nil
+}
+
+// Next returns the first fire time after t, in the schedule's location, or the
+// zero time if there is none.
+func (s *Schedule) Next(t time.Time) time.Time {
+	return s.parser.Next(t)
+}
+
+// Prev returns the last fire time before t, in the schedule's location, or the
+// zero time if there is none.
+func (s *Schedule) Prev(t time.Time) time.Time {
+	return s.parser.Prev(t)
+}
+
+// Occurrences returns the fire times after from, in order. See CronParser.Occurrences.
+func (s *Schedule) Occurrences(from time.Time) iter.Seq[time.Time] {
+	return s.parser.Occurrences(from)
+}
+
+// Between returns the fire times in [start, end), in order.
+func (s *Schedule) Between(start, end time.Time) iter.Seq[time.Time] {
+	return s.parser.Between(start, end)
+}
+
+// NextN returns the next n fire times after t. Fewer are returned if the schedule ends.
+func (s *Schedule) NextN(t time.Time, n int) []time.Time {
+	return s.parser.NextN(t, n)
+}
+
+// Matches reports whether t is a fire time. Precision below the schedule's
+// resolution, a second or a minute without the seconds field, is ignored.
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.parser.Matches(t)
+}
+
+// Expression returns the expression as given to Parse, e.g. "@daily".
+func (s *Schedule) Expression() string {
+	return s.parser.expr
+}
+
+// String returns the fields of the expression after macro expansion and H
+// resolution, e.g. "0 0 * * *" for "@daily".
+func (s *Schedule) String() string {
+	return s.parser.resolved()
+}
+
+// Location returns the time zone fire times are computed in.
+func (s *Schedule) Location() *time.Location {
+	return s.parser.location
+}
+
+// Field returns the sorted values of field f, and false if the expression has no
+// such field (seconds or years without WithSeconds or WithYears). Day tokens that
+// depend on the month, such as L, W and nL, are not included; use Matches to test
+// a particular day.
+func (s *Schedule) Field(f FieldType) ([]int, bool) {
+	var set bitset
+	switch f {
+	case Seconds:
+		if !s.parser.enableSeconds {
+			return nil, false
+		}
+		set = s.parser.seconds
+	case Minutes:
+		set = s.parser.minutes
+	case Hours:
+		set = s.parser.hours
+	case DayOfMonth:
+		set = s.parser.dayOfMonth
+	case Months:
+		set = s.parser.months
+	case DayOfWeek:
+		set = s.parser.dayOfWeek
+	case Years:
+		if !s.parser.enableYears {
+			return nil, false
+		}
+		return slices.Clone(s.parser.years), true
+	default:
+		return nil, false
+	}
+	return set.values(), true
+}
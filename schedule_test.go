@@ -0,0 +1,162 @@
+package golitecron
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse("@daily", WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if s.Expression() != "@daily" || s.String() != "0 0 * * *" || s.Location() != time.UTC {
+		t.Errorf("unexpected schedule %q / %q / %v", s.Expression(), s.String(), s.Location())
+	}
+
+	from := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	if next := s.Next(from); !next.Equal(time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected next %v", next)
+	}
+
+	if _, err := Parse("61 * * * *"); !errors.Is(err, ErrInvalidExpression) {
+		t.Errorf("expected ErrInvalidExpression, got %v", err)
+	}
+}
+
+func TestSchedule_Field(t *testing.T) {
+	s, err := Parse("0 */20 9-11 L * 1-5", WithSeconds())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	tests := []struct {
+		field FieldType
+		want  []int
+		ok    bool
+	}{
+		{Seconds, []int{0}, true},
+		{Minutes, []int{0, 20, 40}, true},
+		{Hours, []int{9, 10, 11}, true},
+		{DayOfMonth, []int{}, true}, // L depends on the month
+		{Months, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, true},
+		{DayOfWeek, []int{1, 2, 3, 4, 5}, true},
+		{Years, nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := s.Field(tt.field)
+		if ok != tt.ok || !slices.Equal(got, tt.want) {
+			t.Errorf("Field(%v) = %v, %v; want %v, %v", tt.field, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	// The returned slice is a copy.
+	minutes, _ := s.Field(Minutes)
+	minutes[0] = 59
+	if again, _ := s.Field(Minutes); again[0] != 0 {
+		t.Error("Field exposed internal state")
+	}
+}
+
+func TestSchedule_Matches(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	tests := []struct {
+		expr string
+		opts []Option
+		t    time.Time
+		want bool
+	}{
+		{"30 9 * * 1-5", nil, time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC), true},  // Tuesday
+		{"30 9 * * 1-5", nil, time.Date(2026, 3, 10, 9, 30, 45, 0, time.UTC), true}, // below resolution
+		{"30 9 * * 1-5", nil, time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC), false}, // Saturday
+		{"30 9 * * 1-5", nil, time.Date(2026, 3, 10, 9, 31, 0, 0, time.UTC), false}, // wrong minute
+		{"15 30 9 * * *", []Option{WithSeconds()}, time.Date(2026, 3, 10, 9, 30, 15, 0, time.UTC), true},
+		{"15 30 9 * * *", []Option{WithSeconds()}, time.Date(2026, 3, 10, 9, 30, 16, 0, time.UTC), false},
+		{"0 0 L * *", nil, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), true},
+		{"0 0 L * *", nil, time.Date(2026, 3, 28, 0, 0, 0, 0, time.UTC), false},
+		{"0 0 1 * 1", nil, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), true}, // OR rule: a Monday
+		{"0 0 * * * 2027", []Option{WithYears()}, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), false},
+		// Compared in the schedule's location: 00:00 UTC is 09:00 in Tokyo.
+		{"0 9 * * *", []Option{WithLocation(tokyo)}, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr, tt.opts...)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", tt.expr, err)
+		}
+		if got := s.Matches(tt.t); got != tt.want {
+			t.Errorf("%q.Matches(%v) = %v, want %v", tt.expr, tt.t, got, tt.want)
+		}
+		if next := s.Next(tt.t.Add(-time.Minute)); tt.want && tt.t.Second() == 0 && !s.Matches(next) {
+			t.Errorf("%q: Next returned non-matching %v", tt.expr, next)
+		}
+	}
+}
+
+func TestScheduler_AddSchedule(t *testing.T) {
+	sched, err := Parse("0 9 * * *", WithTimeout(time.Minute), WithTags("shared"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	s := NewScheduler()
+	for _, id := range []string{"a", "b"} {
+		job, _ := WrapJob(id, func() error { return nil })
+		if err := s.AddSchedule(sched, job); err != nil {
+			t.Fatalf("AddSchedule failed: %v", err)
+		}
+	}
+	job, _ := WrapJob("a", func() error { return nil })
+	if err := s.AddSchedule(sched, job); err == nil {
+		t.Error("expected duplicate ID error")
+	}
+
+	info, ok := s.Task("b")
+	if !ok || info.Expression != "0 9 * * *" || info.Timeout != time.Minute || !slices.Equal(info.Tags, []string{"shared"}) {
+		t.Errorf("unexpected task info %+v", info)
+	}
+	if !sched.Matches(info.NextRunTime) {
+		t.Errorf("next run %v does not match the schedule", info.NextRunTime)
+	}
+}
+
+// TestScheduler_AddScheduleHashSeed tests that H tokens of a shared schedule are seeded by each job's ID
+func TestScheduler_AddScheduleHashSeed(t *testing.T) {
+	sched, err := Parse("H H * * *", WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	seeded, err := Parse("H H * * *", WithHashSeed("fixed"), WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	s := NewScheduler()
+	for _, id := range []string{"backup-db", "backup-files", "seeded-a", "seeded-b"} {
+		job, _ := WrapJob(id, func() error { return nil })
+		schedule := sched
+		if strings.HasPrefix(id, "seeded") {
+			schedule = seeded
+		}
+		if err := s.AddSchedule(schedule, job); err != nil {
+			t.Fatalf("AddSchedule failed: %v", err)
+		}
+	}
+
+	resolved := func(id string) string {
+		info, _ := s.Task(id)
+		return info.ResolvedExpression
+	}
+	if a, b := resolved("backup-db"), resolved("backup-files"); a == b {
+		t.Errorf("expected different offsets for different IDs, both got %q", a)
+	}
+	if a, b := resolved("seeded-a"), resolved("seeded-b"); a != b || a != seeded.String() {
+		t.Errorf("expected the explicit seed to be kept, got %q and %q, want %q", a, b, seeded.String())
+	}
+	if info, _ := s.Task("backup-db"); info.Location != time.UTC {
+		t.Errorf("expected the schedule's settings to be kept, got location %v", info.Location)
+	}
+}
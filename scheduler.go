@@ -21,6 +21,17 @@ const (
 	StorageTypeTimeWheel
 )
 
+func (st StorageType) String() string {
+	switch st {
+	case StorageTypeHeap:
+		return "heap"
+	case StorageTypeTimeWheel:
+		return "timewheel"
+	default:
+		return fmt.Sprintf("StorageType(%d)", int(st))
+	}
+}
+
 // Logger defines the logging interface used by the scheduler.
 type Logger interface {
 	Printf(format string, args ...any)
@@ -37,12 +48,37 @@ func (l *stdLogger) Printf(format string, args ...any) {
 
 type Scheduler struct {
 	taskStorage TaskStorage
+	storageType StorageType
 	logger      Logger
 	wg          sync.WaitGroup
 	stopChan    chan struct{}
 	running     int32
-	mu          sync.Mutex // protects Start/Stop
-	taskMu      sync.Mutex // protects task operations
+	mu          sync.Mutex       // protects Start/Stop
+	taskMu      sync.Mutex       // protects task operations
+	registry    map[string]*Task // all registered tasks, including running ones; guarded by taskMu
+
+	slaHandler func(SLAViolation)
+	stats      map[string]*taskStats
+	statsMu    sync.Mutex
+
+	watchdog     *WatchdogConfig
+	wd           watchdogState
+	lastTickNano int64 // unix nanos of the last completed loop iteration
+
+	profiling ProfilingConfig
+
+	auditSink AuditSink
+
+	eventSubs map[*EventSubscription]struct{}
+	eventsMu  sync.RWMutex
+	eventSeq  atomic.Uint64
+
+	watchers map[*TaskWatch]struct{} // guarded by taskMu
+
+	// IDs of tasks with an execution in progress. Kept per ID rather than on Task,
+	// since rescheduling, pausing and updating replace the registered instance.
+	executing   map[string]struct{}
+	executingMu sync.Mutex
 }
 
 func NewScheduler(storageType ...StorageType) *Scheduler {
@@ -55,12 +91,18 @@ func NewScheduler(storageType ...StorageType) *Scheduler {
 	case StorageTypeTimeWheel:
 		taskStorage = NewDynamicTimeWheel()
 	default:
+		st = StorageTypeHeap
 		taskStorage = NewTaskQueue()
 	}
 	return &Scheduler{
 		taskStorage: taskStorage,
+		storageType: st,
+		registry:    make(map[string]*Task),
 		logger:      &stdLogger{Logger: log.New(os.Stderr, "", log.LstdFlags)},
 		stopChan:    make(chan struct{}),
+		stats:       make(map[string]*taskStats),
+		executing:   make(map[string]struct{}),
+		wd:          watchdogState{inflight: make(map[uint64]*execution)},
 	}
 }
 
@@ -82,29 +124,9 @@ func (s *Scheduler) LoadTasksFromConfig(config *Config) error {
 			return fmt.Errorf("job function %s not found", taskConfig.FuncName)
 		}
 
-		var opts []Option
-		if taskConfig.EnableSeconds {
-			opts = append(opts, WithSeconds())
-		}
-		if taskConfig.EnableYears {
-			opts = append(opts, WithYears())
-		}
-		if taskConfig.Timeout != "" {
-			timeout, err := time.ParseDuration(taskConfig.Timeout)
-			if err != nil {
-				return fmt.Errorf("invalid timeout duration %q for task %s: %w", taskConfig.Timeout, taskConfig.ID, err)
-			}
-			opts = append(opts, WithTimeout(timeout))
-		}
-		if taskConfig.Retry > 0 {
-			opts = append(opts, WithRetry(taskConfig.Retry))
-		}
-		if taskConfig.Location != "" {
-			loc, err := time.LoadLocation(taskConfig.Location)
-			if err != nil {
-				return fmt.Errorf("failed to load location %s: %w", taskConfig.Location, err)
-			}
-			opts = append(opts, WithLocation(loc))
+		opts, err := taskConfig.Options()
+		if err != nil {
+			return err
 		}
 
 		job, err := WrapJob(taskConfig.ID, fn)
@@ -119,68 +141,138 @@ func (s *Scheduler) LoadTasksFromConfig(config *Config) error {
 	return nil
 }
 
+// GetTasks returns the tasks waiting in storage. The returned pointers are shared
+// with the scheduler; use Tasks for a consistent snapshot.
 func (s *Scheduler) GetTasks() []*Task {
 	return s.taskStorage.GetTasks()
 }
 
+// GetTaskInfo returns a one-line description of a task.
+//
+// Deprecated: Use Task, which returns a structured TaskInfo.
 func (s *Scheduler) GetTaskInfo(taskID string) string {
-	tasks := s.taskStorage.GetTasks()
-	for _, task := range tasks {
-		if task.ID == taskID {
-			return fmt.Sprintf("Task ID: %s, Pre Run Time: %s, Next Run Time: %s",
-				task.ID, task.PreRunTime.Format(time.RFC3339), task.NextRunTime.Format(time.RFC3339))
-		}
+	s.taskMu.Lock()
+	task, ok := s.registry[taskID]
+	var pre, next time.Time
+	if ok {
+		// PreRunTime, not TaskInfo.PrevRunTime, as this has always printed.
+		pre, next = task.PreRunTime, task.NextRunTime
 	}
+	s.taskMu.Unlock()
 
-	return fmt.Sprintf("Task with ID %s not found", taskID)
+	if !ok {
+		return fmt.Sprintf("Task with ID %s not found", taskID)
+	}
+	return fmt.Sprintf("Task ID: %s, Pre Run Time: %s, Next Run Time: %s",
+		taskID, pre.Format(time.RFC3339), next.Format(time.RFC3339))
 }
 
 func (s *Scheduler) AddTask(expr string, job Job, opts ...Option) error {
-	parser, err := newCronParser(expr, opts...)
+	return s.AsActor(SystemActor).AddTask(expr, job, opts...)
+}
+
+func (s *Scheduler) addTask(expr string, job Job, opts ...Option) (*TaskDefinition, error) {
+	task, err := newTask(job.ID(), job, expr, opts...)
 	if err != nil {
-		return fmt.Errorf("failed to parse cron expression: %w", err)
+		return nil, err
 	}
+	return s.register(task)
+}
+
+// AddSchedule adds a task running job on an already parsed schedule. Task settings
+// such as timeout and retry are those passed to Parse. H tokens are seeded by the
+// job's ID, as with AddTask, unless Parse was given WithHashSeed.
+func (s *Scheduler) AddSchedule(schedule *Schedule, job Job) error {
+	return s.AsActor(SystemActor).AddSchedule(schedule, job)
+}
 
+func (s *Scheduler) addSchedule(schedule *Schedule, job Job) (*TaskDefinition, error) {
+	parser := schedule.parser
+	if parser.hashSeed == "" {
+		p, err := newCronParser(parser.expr, inheritSettings(parser), WithHashSeed(job.ID()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cron expression: %w: %w", ErrInvalidExpression, err)
+		}
+		parser = p
+	}
+	task, err := newTaskFromParser(job.ID(), job, parser)
+	if err != nil {
+		return nil, err
+	}
+	return s.register(task)
+}
+
+// register adds a new task to the registry and storage.
+func (s *Scheduler) register(task *Task) (*TaskDefinition, error) {
+	s.taskMu.Lock()
+	defer s.taskMu.Unlock()
+
+	if _, exists := s.registry[task.ID]; exists {
+		return nil, fmt.Errorf("task with ID %s already exists", task.ID)
+	}
+	s.registry[task.ID] = task
+	s.taskStorage.AddTask(task)
+	s.taskChanged(EventTaskAdded, task)
+
+	return task.definition(), nil
+}
+
+// newTask parses expr and schedules the first run of a task.
+func newTask(id string, job Job, expr string, opts ...Option) (*Task, error) {
+	// H tokens are seeded by the task ID unless opts set a seed.
+	parser, err := newCronParser(expr, append([]Option{WithHashSeed(id)}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cron expression: %w: %w", ErrInvalidExpression, err)
+	}
+	return newTaskFromParser(id, job, parser)
+}
+
+// newTaskFromParser schedules the first run of a task on a parsed expression.
+func newTaskFromParser(id string, job Job, parser *CronParser) (*Task, error) {
 	nowUTC := time.Now().UTC()
 	nowInTaskZone := nowUTC.In(parser.location)
 	nextRunTime := parser.Next(nowInTaskZone)
 
 	if nextRunTime.IsZero() {
-		return fmt.Errorf("failed to calculate next run time for task %s: cron expression may be invalid or unsatisfiable", job.ID())
+		return nil, fmt.Errorf("failed to calculate next run time for task %s: %w: expression may be unsatisfiable", id, ErrInvalidExpression)
 	}
 
-	task := &Task{
-		ID:          job.ID(),
+	return &Task{
+		ID:          id,
 		Job:         job,
 		CronParser:  parser,
 		NextRunTime: nextRunTime,
 		PreRunTime:  nowInTaskZone,
-	}
-
-	s.taskMu.Lock()
-	defer s.taskMu.Unlock()
-
-	if s.taskStorage.TaskExist(task.ID) {
-		return fmt.Errorf("task with ID %s already exists", task.ID)
-	}
-	s.taskStorage.AddTask(task)
-
-	return nil
+	}, nil
 }
 
 func (s *Scheduler) RemoveTask(task *Task) bool {
+	return s.AsActor(SystemActor).removeTask(task)
+}
+
+func (s *Scheduler) removeTask(task *Task) (*TaskDefinition, bool) {
 	atomic.StoreInt32(&task.Removed, 1)
 
 	s.taskMu.Lock()
 	defer s.taskMu.Unlock()
 
-	if !s.taskStorage.TaskExist(task.ID) {
-		return false
+	current, exists := s.registry[task.ID]
+	if !exists {
+		return nil, false
 	}
+	before := current.definition()
+	s.unregisterLocked(current)
+	s.dropTaskStats(task.ID)
 
-	s.taskStorage.RemoveTask(task)
+	return before, true
+}
 
-	return true
+// unregisterLocked drops a task from the registry and storage. Caller must hold taskMu.
+func (s *Scheduler) unregisterLocked(task *Task) {
+	atomic.StoreInt32(&task.Removed, 1)
+	delete(s.registry, task.ID)
+	s.taskStorage.RemoveTask(task)
+	s.taskChanged(EventTaskRemoved, task)
 }
 
 func (s *Scheduler) Start() {
@@ -192,8 +284,14 @@ func (s *Scheduler) Start() {
 	}
 	atomic.StoreInt32(&s.running, 1)
 	s.stopChan = make(chan struct{})
+	s.markTick(time.Now())
 	s.wg.Add(1)
 	go s.run()
+
+	if s.watchdog != nil {
+		s.wg.Add(1)
+		go s.runWatchdog(s.stopChan)
+	}
 }
 
 func (s *Scheduler) Stop() {
@@ -221,99 +319,204 @@ func (s *Scheduler) run() {
 		case <-ticker.C:
 			nowUTC := time.Now().UTC()
 			tasksToExecute := s.taskStorage.Tick(nowUTC)
-			if len(tasksToExecute) == 0 {
-				continue
-			}
-
 			for _, task := range tasksToExecute {
 				s.wg.Add(1)
-				go func(t *Task) {
-					defer func() {
-						s.wg.Done()
-						if r := recover(); r != nil {
-							s.logger.Printf("Recovered from panic in task %s: %v\n", t.ID, r)
-						}
-					}()
-
-					if !atomic.CompareAndSwapInt32(&t.Running, 0, 1) {
-						return
-					}
-					defer atomic.StoreInt32(&t.Running, 0)
-
-					// timeout control
-					var err error
-					timeout := t.CronParser.timeout
-					timedOut := false
-
-					for i := 0; i < t.CronParser.retry+1; i++ {
-						if timeout > 0 {
-							ctx, cancel := context.WithTimeout(context.Background(), timeout)
-
-							done := make(chan error, 1)
-							go func() {
-								defer func() {
-									if r := recover(); r != nil {
-										done <- fmt.Errorf("panic in task %s: %v", t.ID, r)
-									}
-								}()
-								done <- t.Job.Execute(ctx)
-							}()
-
-							select {
-							case err = <-done:
-							case <-ctx.Done():
-								err = fmt.Errorf("task %s timed out after %s", t.ID, timeout)
-								timedOut = true
-							}
-
-							cancel()
-
-							if timedOut {
-								s.logger.Printf("Task %s timed out, skipping retries to prevent goroutine accumulation\n", t.ID)
-								break
-							}
-						} else {
-							err = t.Job.Execute(context.Background())
-						}
-
-						if err != nil {
-							s.logger.Printf("Error executing task %s: %v (retry %d)\n", t.ID, err, i)
-						} else {
-							break
-						}
-					}
-					// Calculate next run time in task's timezone
-					nowUTC := time.Now().UTC()
-					nowInTaskZone := nowUTC.In(t.CronParser.location)
-					nextRunTime := t.CronParser.Next(nowInTaskZone)
-
-					if nextRunTime.IsZero() {
-						s.logger.Printf("Task %s: failed to calculate next run time, task will not be rescheduled\n", t.ID)
-						return
-					}
+				go s.runTask(task)
+			}
+			s.markTick(time.Now())
+		}
+	}
+}
 
-					if atomic.LoadInt32(&s.running) == 0 {
-						return
-					}
+// runTask executes a due task with timeout/retry control and reschedules it.
+func (s *Scheduler) runTask(t *Task) {
+	defer func() {
+		s.wg.Done()
+		if r := recover(); r != nil {
+			s.logger.Printf("Recovered from panic in task %s: %v\n", t.ID, r)
+		}
+	}()
 
-					updateTask := &Task{
-						ID:          t.ID,
-						Job:         t.Job,
-						CronParser:  t.CronParser,
-						NextRunTime: nextRunTime,
-						PreRunTime:  nowInTaskZone,
-						Running:     0,
-					}
+	if !s.isCurrent(t) {
+		return // removed between Tick and dispatch
+	}
 
-					s.taskMu.Lock()
-					if atomic.LoadInt32(&t.Removed) == 1 {
-						s.taskMu.Unlock()
-						return
+	switch {
+	case atomic.LoadInt32(&t.Paused) == 1:
+		// paused between Tick and dispatch
+	case s.claimRun(t):
+		started := time.Now()
+		s.recordStart(t, started)
+		execID := s.beginExecution(t, started)
+		s.publishFor(t, Event{Type: EventExecutionStarted, Time: started, ExecutionID: execID, Scheduled: t.NextRunTime})
+		err := s.execute(t, execID)
+		finished := time.Now()
+		s.endExecution(execID)
+		rec := ExecutionRecord{
+			ID:        execID,
+			Scheduled: t.NextRunTime,
+			Started:   started,
+			Finished:  finished,
+		}
+		s.recordOutcome(t, rec, err)
+		s.publishFinished(t, rec, err)
+		s.recordCompletion(t, finished)
+		s.releaseRun(t)
+	default:
+		// A triggered execution is still running; this fire is skipped.
+		s.logger.Printf("Task %s: skipping fire at %s, previous execution still running\n",
+			t.ID, t.NextRunTime.Format(time.RFC3339))
+		s.recordSkipped(t)
+		s.publishFor(t, Event{Type: EventSkipped, Scheduled: t.NextRunTime})
+	}
+
+	s.reschedule(t)
+}
+
+// claimRun marks t as executing. It reports false if an execution of a task with
+// the same ID is already in progress, on this or an earlier instance.
+func (s *Scheduler) claimRun(t *Task) bool {
+	s.executingMu.Lock()
+	defer s.executingMu.Unlock()
+	if _, busy := s.executing[t.ID]; busy {
+		return false
+	}
+	s.executing[t.ID] = struct{}{}
+	atomic.StoreInt32(&t.Running, 1)
+	return true
+}
+
+// releaseRun ends the execution claimed by claimRun.
+func (s *Scheduler) releaseRun(t *Task) {
+	s.executingMu.Lock()
+	defer s.executingMu.Unlock()
+	delete(s.executing, t.ID)
+	atomic.StoreInt32(&t.Running, 0)
+}
+
+// isExecuting reports whether an execution of the task with the given ID is in progress.
+func (s *Scheduler) isExecuting(taskID string) bool {
+	s.executingMu.Lock()
+	defer s.executingMu.Unlock()
+	_, busy := s.executing[taskID]
+	return busy
+}
+
+// reschedule registers the next run of t, calculated from now.
+func (s *Scheduler) reschedule(t *Task) {
+	// Calculate next run time in task's timezone. It is the fire after the one that
+	// ran, unless that has passed: rescheduling continues from the current time, so
+	// the fires through now were missed, lost to an overrunning execution or to a
+	// stopped scheduler.
+	nowInTaskZone := time.Now().In(t.CronParser.location)
+	nextRunTime := t.CronParser.Next(t.NextRunTime)
+	var missed []time.Time
+	if !nextRunTime.IsZero() && !nextRunTime.After(nowInTaskZone) {
+		missed = missedFires(t.CronParser, nextRunTime, nowInTaskZone)
+		nextRunTime = t.CronParser.Next(nowInTaskZone)
+	}
+
+	s.taskMu.Lock()
+	if s.registry[t.ID] != t || atomic.LoadInt32(&t.Removed) == 1 {
+		s.taskMu.Unlock()
+		return // removed or replaced while running; its missed fires no longer count
+	}
+	s.requeueLocked(t, nextRunTime, nowInTaskZone)
+	s.taskMu.Unlock()
+
+	// Reported after unlocking, since stats lookups take taskMu.
+	s.reportMissed(t, missed, nowInTaskZone)
+}
+
+// requeueLocked registers the instance of t that runs next, or drops t if it has no
+// next run or the scheduler was stopped. Caller must hold taskMu.
+func (s *Scheduler) requeueLocked(t *Task, nextRunTime, nowInTaskZone time.Time) {
+	if nextRunTime.IsZero() {
+		s.logger.Printf("Task %s: failed to calculate next run time, task will not be rescheduled\n", t.ID)
+		s.unregisterLocked(t)
+		return
+	}
+
+	// Tasks running at Stop() are not rescheduled.
+	if atomic.LoadInt32(&s.running) == 0 {
+		s.unregisterLocked(t)
+		return
+	}
+
+	updateTask := &Task{
+		ID:          t.ID,
+		Job:         t.Job,
+		CronParser:  t.CronParser,
+		NextRunTime: nextRunTime,
+		PreRunTime:  nowInTaskZone,
+		Running:     0,
+		Paused:      atomic.LoadInt32(&t.Paused),
+	}
+	s.registry[t.ID] = updateTask
+	if updateTask.Paused == 0 {
+		s.taskStorage.AddTask(updateTask)
+	}
+}
+
+// isCurrent reports whether t is the registered instance of its task.
+func (s *Scheduler) isCurrent(t *Task) bool {
+	s.taskMu.Lock()
+	defer s.taskMu.Unlock()
+	return s.registry[t.ID] == t
+}
+
+// attempt runs the job once and then once per retry until it succeeds.
+// A timed-out attempt ends the execution.
+func (s *Scheduler) attempt(ctx context.Context, t *Task, execID uint64) error {
+	// timeout control
+	var err error
+	timeout := t.CronParser.timeout
+	timedOut := false
+
+	for i := 0; i < t.CronParser.retry+1; i++ {
+		region := s.startAttemptRegion(ctx, i)
+
+		if timeout > 0 {
+			attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+
+			done := make(chan error, 1)
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						done <- fmt.Errorf("panic in task %s: %v", t.ID, r)
 					}
-					s.taskStorage.AddTask(updateTask)
-					s.taskMu.Unlock()
-				}(task)
+				}()
+				done <- t.Job.Execute(attemptCtx)
+			}()
+
+			select {
+			case err = <-done:
+			case <-attemptCtx.Done():
+				err = fmt.Errorf("task %s timed out after %s", t.ID, timeout)
+				timedOut = true
 			}
+
+			cancel()
+
+			if timedOut {
+				region.End()
+				s.publishFor(t, Event{Type: EventTimeout, ExecutionID: execID, Attempt: i, Error: err.Error()})
+				s.logger.Printf("Task %s timed out, skipping retries to prevent goroutine accumulation\n", t.ID)
+				break
+			}
+		} else {
+			err = t.Job.Execute(ctx)
+		}
+		region.End()
+
+		if err == nil {
+			break
+		}
+		s.logger.Printf("Error executing task %s: %v (retry %d)\n", t.ID, err, i)
+		if i < t.CronParser.retry {
+			s.publishFor(t, Event{Type: EventRetry, ExecutionID: execID, Attempt: i, Error: err.Error()})
 		}
 	}
+
+	return err
 }
@@ -64,6 +64,9 @@ func TestNewScheduler_AddGetRemove(t *testing.T) {
 	if !strings.Contains(info, "job1") {
 		t.Fatalf("GetTaskInfo did not contain job id: %s", info)
 	}
+	if !strings.Contains(info, "Pre Run Time: "+tasks[0].PreRunTime.Format(time.RFC3339)) || strings.Contains(info, "0001-01-01") {
+		t.Errorf("GetTaskInfo should print the task's PreRunTime: %s", info)
+	}
 
 	// RemoveTask should return true when task exists and is removed
 	removed := s.RemoveTask(tasks[0])
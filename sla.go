@@ -0,0 +1,168 @@
+package golitecron
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxMissedFires caps how many skipped fires are reported for a single execution,
+// so that a long-running per-second task cannot flood the SLA handler.
+const maxMissedFires = 100
+
+// SLAViolationKind describes which SLA bound was breached.
+type SLAViolationKind int
+
+const (
+	// SLAStartDelay means an execution started later than the allowed delay.
+	SLAStartDelay SLAViolationKind = iota
+	// SLACompletion means an execution finished later than the allowed time after its scheduled fire.
+	SLACompletion
+	// SLAMissed means a scheduled fire never ran: the previous execution overran it,
+	// the task was paused or the scheduler was stopped.
+	SLAMissed
+)
+
+func (k SLAViolationKind) String() string {
+	switch k {
+	case SLAStartDelay:
+		return "start_delay"
+	case SLACompletion:
+		return "completion"
+	case SLAMissed:
+		return "missed"
+	default:
+		return fmt.Sprintf("SLAViolationKind(%d)", int(k))
+	}
+}
+
+// SLAViolation is passed to the SLA handler when a task breaches its SLA.
+type SLAViolation struct {
+	TaskID    string
+	Kind      SLAViolationKind
+	Scheduled time.Time     // intended fire time
+	Observed  time.Duration // measured lag behind Scheduled
+	Limit     time.Duration // configured bound; zero for SLAMissed
+}
+
+// WithSLAHandler sets the callback invoked on SLA violations. Must be called before Start().
+// Without a handler, violations are written to the logger.
+func (s *Scheduler) WithSLAHandler(fn func(SLAViolation)) {
+	s.slaHandler = fn
+}
+
+// recordStart measures the lag between the intended NextRunTime and the actual start.
+func (s *Scheduler) recordStart(t *Task, started time.Time) {
+	delay := max(started.Sub(t.NextRunTime), 0)
+
+	limit := t.CronParser.slaStartDelay
+	breached := limit > 0 && delay > limit
+	if st := s.taskStats(t.ID); st != nil {
+		st.mu.Lock()
+		st.LastScheduled = t.NextRunTime
+		st.LastStarted = started
+		st.LastStartDelay = delay
+		st.MaxStartDelay = max(st.MaxStartDelay, delay)
+		if breached {
+			st.StartDelayBreaches++
+		}
+		st.mu.Unlock()
+	}
+
+	if breached {
+		s.reportSLAViolation(SLAViolation{
+			TaskID:    t.ID,
+			Kind:      SLAStartDelay,
+			Scheduled: t.NextRunTime,
+			Observed:  delay,
+			Limit:     limit,
+		})
+	}
+}
+
+// recordCompletion measures the lag between the intended NextRunTime and completion.
+func (s *Scheduler) recordCompletion(t *Task, finished time.Time) {
+	delay := max(finished.Sub(t.NextRunTime), 0)
+
+	limit := t.CronParser.slaCompletion
+	breached := limit > 0 && delay > limit
+	if st := s.taskStats(t.ID); st != nil {
+		st.mu.Lock()
+		st.LastFinished = finished
+		st.LastCompletionDelay = delay
+		st.MaxCompletionDelay = max(st.MaxCompletionDelay, delay)
+		if breached {
+			st.CompletionBreaches++
+		}
+		st.mu.Unlock()
+	}
+
+	if breached {
+		s.reportSLAViolation(SLAViolation{
+			TaskID:    t.ID,
+			Kind:      SLACompletion,
+			Scheduled: t.NextRunTime,
+			Observed:  delay,
+			Limit:     limit,
+		})
+	}
+}
+
+// missedFires returns the fire times from first through now, at most maxMissedFires.
+func missedFires(p *CronParser, first, now time.Time) []time.Time {
+	var missed []time.Time
+	for fire := first; !fire.IsZero() && !fire.After(now); fire = p.Next(fire) {
+		missed = append(missed, fire)
+		if len(missed) == maxMissedFires {
+			break
+		}
+	}
+	return missed
+}
+
+// reportMissed counts missed fires for any task and reports them as violations for
+// tasks with an SLA. Caller must not hold taskMu.
+func (s *Scheduler) reportMissed(t *Task, missed []time.Time, now time.Time) {
+	if len(missed) == 0 {
+		return
+	}
+	if st := s.taskStats(t.ID); st != nil {
+		st.mu.Lock()
+		st.MissedFires += uint64(len(missed))
+		st.mu.Unlock()
+	}
+	if !t.CronParser.hasSLA() {
+		return
+	}
+	for _, fire := range missed {
+		s.reportSLAViolation(SLAViolation{
+			TaskID:    t.ID,
+			Kind:      SLAMissed,
+			Scheduled: fire,
+			Observed:  now.Sub(fire),
+		})
+	}
+}
+
+// recordSkipped records a fire that was dropped because the task was still running.
+func (s *Scheduler) recordSkipped(t *Task) {
+	now := time.Now()
+	if now.Before(t.NextRunTime) {
+		now = t.NextRunTime
+	}
+	s.reportMissed(t, []time.Time{t.NextRunTime}, now)
+}
+
+func (s *Scheduler) reportSLAViolation(v SLAViolation) {
+	if s.slaHandler == nil {
+		s.logger.Printf("Task %s: SLA violation (%s): scheduled %s, observed %s, limit %s\n",
+			v.TaskID, v.Kind, v.Scheduled.Format(time.RFC3339), v.Observed, v.Limit)
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Printf("Recovered from panic in SLA handler for task %s: %v\n", v.TaskID, r)
+		}
+	}()
+	s.slaHandler(v)
+}
@@ -0,0 +1,233 @@
+package golitecron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// collectViolations returns an SLA handler that records violations and a getter for them.
+func collectViolations() (func(SLAViolation), func() []SLAViolation) {
+	var mu sync.Mutex
+	var got []SLAViolation
+	handler := func(v SLAViolation) {
+		mu.Lock()
+		got = append(got, v)
+		mu.Unlock()
+	}
+	snapshot := func() []SLAViolation {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]SLAViolation(nil), got...)
+	}
+	return handler, snapshot
+}
+
+func countKind(vs []SLAViolation, kind SLAViolationKind) int {
+	n := 0
+	for _, v := range vs {
+		if v.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+// TestSLA_StartDelayAndCompletion tests that breaches of both bounds are reported and counted
+func TestSLA_StartDelayAndCompletion(t *testing.T) {
+	s := NewScheduler()
+	handler, violations := collectViolations()
+	s.WithSLAHandler(handler)
+
+	job, _ := WrapJob("sla-breach", func() error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	// The tick granularity alone makes a 1ns start bound unattainable.
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC),
+		WithSLA(time.Nanosecond, 10*time.Millisecond)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	time.Sleep(1500 * time.Millisecond)
+	s.Stop()
+
+	vs := violations()
+	if countKind(vs, SLAStartDelay) == 0 {
+		t.Fatalf("expected start delay violations, got %v", vs)
+	}
+	if countKind(vs, SLACompletion) == 0 {
+		t.Fatalf("expected completion violations, got %v", vs)
+	}
+	for _, v := range vs {
+		if v.TaskID != "sla-breach" {
+			t.Errorf("unexpected task ID %q", v.TaskID)
+		}
+		if v.Kind != SLAMissed && v.Observed <= v.Limit {
+			t.Errorf("violation %s observed %s within limit %s", v.Kind, v.Observed, v.Limit)
+		}
+	}
+
+	stats, ok := s.TaskStats("sla-breach")
+	if !ok {
+		t.Fatal("expected stats for task")
+	}
+	if stats.StartDelayBreaches == 0 || stats.CompletionBreaches == 0 {
+		t.Errorf("expected breach counters to be set, got %+v", stats)
+	}
+	if stats.LastScheduled.IsZero() || stats.LastFinished.Before(stats.LastStarted) {
+		t.Errorf("inconsistent timestamps: %+v", stats)
+	}
+}
+
+// TestSLA_MissedFires tests that fires overrun by a long execution are reported as missed
+func TestSLA_MissedFires(t *testing.T) {
+	s := NewScheduler()
+	handler, violations := collectViolations()
+	s.WithSLAHandler(handler)
+
+	job, _ := WrapJob("sla-missed", func() error {
+		time.Sleep(2200 * time.Millisecond)
+		return nil
+	})
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC),
+		WithSLA(time.Hour, time.Hour)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	time.Sleep(3500 * time.Millisecond)
+	s.Stop()
+
+	vs := violations()
+	if got := countKind(vs, SLAMissed); got < 2 {
+		t.Fatalf("expected at least 2 missed fires, got %d (%v)", got, vs)
+	}
+	if countKind(vs, SLAStartDelay)+countKind(vs, SLACompletion) != 0 {
+		t.Errorf("did not expect start/completion violations with generous bounds: %v", vs)
+	}
+
+	stats, _ := s.TaskStats("sla-missed")
+	if stats.MissedFires < 2 {
+		t.Errorf("expected MissedFires >= 2, got %d", stats.MissedFires)
+	}
+}
+
+// TestSLA_MissedFiresWithoutSLA tests that missed fires are counted for tasks without an SLA
+func TestSLA_MissedFiresWithoutSLA(t *testing.T) {
+	s := NewScheduler()
+	handler, violations := collectViolations()
+	s.WithSLAHandler(handler)
+
+	job, _ := WrapJob("overrun", func() error {
+		time.Sleep(2200 * time.Millisecond)
+		return nil
+	})
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	time.Sleep(3500 * time.Millisecond)
+	s.Stop()
+
+	if vs := violations(); len(vs) != 0 {
+		t.Fatalf("expected no violations without an SLA, got %v", vs)
+	}
+	if stats, _ := s.TaskStats("overrun"); stats.MissedFires < 2 {
+		t.Errorf("expected MissedFires >= 2, got %d", stats.MissedFires)
+	}
+}
+
+// TestSLA_NoMissedAfterRemoval tests that a task removed while running reports no missed fires
+func TestSLA_NoMissedAfterRemoval(t *testing.T) {
+	s := NewScheduler()
+	handler, violations := collectViolations()
+	s.WithSLAHandler(handler)
+
+	started := make(chan struct{}, 1)
+	job, _ := WrapJob("removed", func() error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		time.Sleep(2200 * time.Millisecond)
+		return nil
+	})
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC),
+		WithSLA(time.Hour, time.Hour)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	select {
+	case <-started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("task did not start")
+	}
+	if !s.RemoveTaskByID("removed") {
+		t.Fatal("RemoveTaskByID failed")
+	}
+	time.Sleep(2500 * time.Millisecond) // the execution finishes after overrunning two fires
+	s.Stop()
+
+	if vs := violations(); countKind(vs, SLAMissed) != 0 {
+		t.Errorf("expected no missed fires after removal, got %v", vs)
+	}
+}
+
+// TestSLA_MissedWhilePaused tests that fires lost while a task is paused are reported on resume
+func TestSLA_MissedWhilePaused(t *testing.T) {
+	s := NewScheduler()
+	handler, violations := collectViolations()
+	s.WithSLAHandler(handler)
+
+	job, _ := WrapJob("paused", func() error { return nil })
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC),
+		WithSLA(time.Hour, time.Hour)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if err := s.PauseTask("paused"); err != nil {
+		t.Fatalf("PauseTask failed: %v", err)
+	}
+	time.Sleep(2500 * time.Millisecond)
+	if err := s.ResumeTask("paused"); err != nil {
+		t.Fatalf("ResumeTask failed: %v", err)
+	}
+
+	missed := countKind(violations(), SLAMissed)
+	if missed < 2 || missed > 3 {
+		t.Errorf("expected 2-3 missed fires while paused, got %d", missed)
+	}
+	if stats, _ := s.TaskStats("paused"); stats.MissedFires != uint64(missed) {
+		t.Errorf("expected MissedFires %d, got %d", missed, stats.MissedFires)
+	}
+}
+
+// TestSLA_NoBoundsMeasuresOnly tests that tasks without an SLA are measured but never reported
+func TestSLA_NoBoundsMeasuresOnly(t *testing.T) {
+	s := NewScheduler()
+	handler, violations := collectViolations()
+	s.WithSLAHandler(handler)
+
+	job, _ := WrapJob("sla-none", func() error { return nil })
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	time.Sleep(1500 * time.Millisecond)
+	s.Stop()
+
+	if vs := violations(); len(vs) != 0 {
+		t.Fatalf("expected no violations, got %v", vs)
+	}
+	stats, ok := s.TaskStats("sla-none")
+	if !ok || stats.LastStarted.IsZero() {
+		t.Fatalf("expected lateness to be measured, got %+v", stats)
+	}
+	if stats.LastStartDelay > stats.MaxStartDelay {
+		t.Errorf("last delay %s exceeds max %s", stats.LastStartDelay, stats.MaxStartDelay)
+	}
+}
@@ -0,0 +1,183 @@
+// Package sse streams scheduler events to HTTP clients as Server-Sent Events.
+//
+// Each cron.Event is sent with its type as the SSE event name and its sequence
+// number as the id:
+//
+//	event: execution_finished
+//	id: 42
+//	data: {"seq":42,"type":"execution_finished","task_id":"report",...}
+//
+// Query parameters filter the stream; repeat them to allow several values:
+//
+//	?task=report&task=cleanup   only events of these tasks
+//	?tag=billing                only events of tasks with one of these tags
+//
+// Every client has a bounded buffer. When a client falls behind, events are
+// dropped rather than delaying the scheduler, and the client is sent a
+// "dropped" event with the total number of events it missed. Missed events are
+// not replayed on reconnect.
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+const (
+	// DefaultBufferSize is the number of events buffered per client.
+	DefaultBufferSize = 256
+	// DefaultHeartbeat is the interval of keep-alive comments on idle streams.
+	DefaultHeartbeat = 15 * time.Second
+)
+
+// Option configures the handler.
+type Option func(*handler)
+
+// WithBufferSize sets the number of events buffered per client before events are dropped.
+func WithBufferSize(n int) Option {
+	return func(h *handler) {
+		if n > 0 {
+			h.bufferSize = n
+		}
+	}
+}
+
+// WithHeartbeat sets the interval of keep-alive comments, which keep proxies from
+// closing idle streams.
+func WithHeartbeat(d time.Duration) Option {
+	return func(h *handler) {
+		if d > 0 {
+			h.heartbeat = d
+		}
+	}
+}
+
+// Event is the JSON data of an SSE message.
+type Event struct {
+	Seq         uint64     `json:"seq"`
+	Type        string     `json:"type"`
+	Time        time.Time  `json:"time"`
+	TaskID      string     `json:"task_id"`
+	Tags        []string   `json:"tags,omitempty"`
+	ExecutionID uint64     `json:"execution_id,omitempty"`
+	Scheduled   *time.Time `json:"scheduled,omitempty"`
+	Triggered   bool       `json:"triggered,omitempty"`
+	Attempt     int        `json:"attempt,omitempty"`
+	DurationMS  float64    `json:"duration_ms,omitempty"`
+	Error       string     `json:"error,omitempty"`
+}
+
+// NewEvent converts a cron.Event into its JSON representation.
+func NewEvent(e cron.Event) Event {
+	ev := Event{
+		Seq:         e.Seq,
+		Type:        e.Type.String(),
+		Time:        e.Time,
+		TaskID:      e.TaskID,
+		Tags:        e.Tags,
+		ExecutionID: e.ExecutionID,
+		Triggered:   e.Triggered,
+		Attempt:     e.Attempt,
+		DurationMS:  float64(e.Duration) / float64(time.Millisecond),
+		Error:       e.Error,
+	}
+	if !e.Scheduled.IsZero() {
+		ev.Scheduled = &e.Scheduled
+	}
+	return ev
+}
+
+// NewHandler returns an http.Handler streaming the events of s.
+func NewHandler(s *cron.Scheduler, opts ...Option) http.Handler {
+	h := &handler{
+		scheduler:  s,
+		bufferSize: DefaultBufferSize,
+		heartbeat:  DefaultHeartbeat,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+type handler struct {
+	scheduler  *cron.Scheduler
+	bufferSize int
+	heartbeat  time.Duration
+}
+
+type filter struct {
+	tasks []string
+	tags  []string
+}
+
+func (f filter) match(e cron.Event) bool {
+	if len(f.tasks) > 0 && !slices.Contains(f.tasks, e.TaskID) {
+		return false
+	}
+	if len(f.tags) > 0 && !slices.ContainsFunc(e.Tags, func(tag string) bool {
+		return slices.Contains(f.tags, tag)
+	}) {
+		return false
+	}
+	return true
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	query := r.URL.Query()
+	f := filter{tasks: query["task"], tags: query["tag"]}
+
+	sub := h.scheduler.Events(h.bufferSize)
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // disable proxy buffering, e.g. nginx
+	w.WriteHeader(http.StatusOK)
+	// Tell clients how long to wait before reconnecting, in milliseconds.
+	fmt.Fprint(w, "retry: 3000\n\n")
+	if err := rc.Flush(); err != nil {
+		return // streaming not supported by the ResponseWriter
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	var reportedDrops uint64
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-sub.C():
+			if !f.match(e) {
+				continue
+			}
+			data, err := json.Marshal(NewEvent(e))
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\nid: %d\ndata: %s\n\n", e.Type, e.Seq, data)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		if dropped := sub.Dropped(); dropped > reportedDrops {
+			reportedDrops = dropped
+			fmt.Fprintf(w, "event: dropped\ndata: {\"dropped\":%d}\n\n", dropped)
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
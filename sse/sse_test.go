@@ -0,0 +1,195 @@
+package sse
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cron "github.com/hansir-hsj/GoLiteCron"
+)
+
+type message struct {
+	event string
+	data  string
+}
+
+// readMessages reads SSE messages from the stream until n have arrived.
+func readMessages(t *testing.T, r *bufio.Reader, n int) []message {
+	t.Helper()
+	var msgs []message
+	var cur message
+	for len(msgs) < n {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read failed after %d messages: %v", len(msgs), err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if cur.event != "" {
+				msgs = append(msgs, cur)
+			}
+			cur = message{}
+		case strings.HasPrefix(line, "event: "):
+			cur.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			cur.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+	return msgs
+}
+
+func subscribe(t *testing.T, url string) *bufio.Reader {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	r := bufio.NewReader(resp.Body)
+	if line, _ := r.ReadString('\n'); !strings.HasPrefix(line, "retry:") {
+		t.Fatalf("expected retry preamble, got %q", line)
+	}
+	return r
+}
+
+func TestSSE_Filtering(t *testing.T) {
+	s := cron.NewScheduler()
+	srv := httptest.NewServer(NewHandler(s))
+	t.Cleanup(srv.Close) // runs after the subscriptions are cancelled
+
+	all := subscribe(t, srv.URL)
+	byTask := subscribe(t, srv.URL+"?task=b")
+	byTag := subscribe(t, srv.URL+"?tag=billing&tag=ops")
+	time.Sleep(50 * time.Millisecond) // let the handlers subscribe
+
+	for _, id := range []string{"a", "b", "c"} {
+		job, _ := cron.WrapJob(id, func() error { return nil })
+		var opts []cron.Option
+		if id == "c" {
+			opts = append(opts, cron.WithTags("ops"))
+		}
+		if err := s.AddTask("0 9 * * *", job, opts...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	msgs := readMessages(t, all, 3)
+	for i, id := range []string{"a", "b", "c"} {
+		var e Event
+		if err := json.Unmarshal([]byte(msgs[i].data), &e); err != nil {
+			t.Fatal(err)
+		}
+		if msgs[i].event != "task_added" || e.Type != "task_added" || e.TaskID != id {
+			t.Errorf("message %d: unexpected %+v", i, msgs[i])
+		}
+	}
+	if msgs := readMessages(t, byTask, 1); !strings.Contains(msgs[0].data, `"task_id":"b"`) {
+		t.Errorf("task filter: unexpected %+v", msgs[0])
+	}
+	if msgs := readMessages(t, byTag, 1); !strings.Contains(msgs[0].data, `"task_id":"c"`) {
+		t.Errorf("tag filter: unexpected %+v", msgs[0])
+	}
+}
+
+// gatedWriter is a streaming ResponseWriter whose writes block while its gate is shut.
+type gatedWriter struct {
+	mu     sync.Mutex
+	header http.Header
+	buf    bytes.Buffer
+	gate   chan struct{}
+	wrote  chan struct{}
+}
+
+func (w *gatedWriter) Header() http.Header { return w.header }
+func (w *gatedWriter) WriteHeader(int)     {}
+func (w *gatedWriter) Flush()              {}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	gate := w.gate
+	w.mu.Unlock()
+	<-gate
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	select {
+	case w.wrote <- struct{}{}:
+	default:
+	}
+	return w.buf.Write(p)
+}
+
+func (w *gatedWriter) setGate(gate chan struct{}) {
+	w.mu.Lock()
+	w.gate = gate
+	w.mu.Unlock()
+}
+
+func (w *gatedWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.String()
+}
+
+func TestSSE_ReportsDrops(t *testing.T) {
+	s := cron.NewScheduler()
+	h := NewHandler(s, WithBufferSize(1))
+
+	open := make(chan struct{})
+	close(open)
+	w := &gatedWriter{header: http.Header{}, gate: open, wrote: make(chan struct{}, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Wait for the preamble, then stall the client while events are published:
+	// one is being written, one is buffered and the rest are dropped.
+	<-w.wrote
+	shut := make(chan struct{})
+	w.setGate(shut)
+	for i := 0; i < 6; i++ {
+		job, _ := cron.WrapJob(fmt.Sprintf("task-%d", i), func() error { return nil })
+		if err := s.AddTask("0 9 * * *", job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(shut)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(w.String(), "event: dropped\n") {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected a dropped notice, got:\n%s", w.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSSE_MethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewHandler(cron.NewScheduler()).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected 405, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,165 @@
+package golitecron
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// TaskStats holds execution outcomes and scheduling lateness measurements for a task.
+type TaskStats struct {
+	Runs                uint64
+	Failures            uint64
+	ConsecutiveFailures uint64
+	LastError           string
+
+	LastScheduled       time.Time
+	LastStarted         time.Time
+	LastFinished        time.Time
+	LastStartDelay      time.Duration // LastStarted - LastScheduled
+	LastCompletionDelay time.Duration // LastFinished - LastScheduled
+	MaxStartDelay       time.Duration
+	MaxCompletionDelay  time.Duration
+
+	StartDelayBreaches uint64
+	CompletionBreaches uint64
+	MissedFires        uint64
+}
+
+// executionHistorySize is the number of executions kept per task for RecentExecutions.
+const executionHistorySize = 20
+
+// ExecutionRecord describes one finished execution of a task.
+type ExecutionRecord struct {
+	ID        uint64
+	Scheduled time.Time // intended fire time; zero for triggered executions
+	Started   time.Time
+	Finished  time.Time
+	Error     string // empty on success
+	Triggered bool   // run on demand via TriggerTask
+}
+
+// Duration returns how long the execution took, including retries.
+func (r ExecutionRecord) Duration() time.Duration {
+	return r.Finished.Sub(r.Started)
+}
+
+type taskStats struct {
+	mu sync.Mutex
+	TaskStats
+	history []ExecutionRecord // ring buffer, next holds the oldest entry once full
+	next    int
+}
+
+// TaskStats returns the execution statistics of a task.
+func (s *Scheduler) TaskStats(taskID string) (TaskStats, bool) {
+	s.statsMu.Lock()
+	st, ok := s.stats[taskID]
+	s.statsMu.Unlock()
+	if !ok {
+		return TaskStats{}, false
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	return st.TaskStats, true
+}
+
+// taskStats returns the stats entry for taskID, creating it if needed, or nil if
+// the task is no longer registered. Executions that finish after their task was
+// removed are not recorded, so a task added later with the same ID starts afresh.
+// Caller must not hold taskMu.
+func (s *Scheduler) taskStats(taskID string) *taskStats {
+	s.taskMu.Lock()
+	defer s.taskMu.Unlock()
+	if _, ok := s.registry[taskID]; !ok {
+		return nil
+	}
+
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	st, ok := s.stats[taskID]
+	if !ok {
+		st = &taskStats{}
+		s.stats[taskID] = st
+	}
+	return st
+}
+
+func (s *Scheduler) dropTaskStats(taskID string) {
+	s.statsMu.Lock()
+	delete(s.stats, taskID)
+	s.statsMu.Unlock()
+}
+
+// RecentExecutions returns up to the last 20 executions of a task, newest first.
+func (s *Scheduler) RecentExecutions(taskID string) []ExecutionRecord {
+	s.statsMu.Lock()
+	st, ok := s.stats[taskID]
+	s.statsMu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	records := make([]ExecutionRecord, 0, len(st.history))
+	for i := 1; i <= len(st.history); i++ {
+		records = append(records, st.history[(st.next-i+len(st.history))%len(st.history)])
+	}
+	return records
+}
+
+// recordOutcome records the final result of an execution, after retries.
+func (s *Scheduler) recordOutcome(t *Task, rec ExecutionRecord, err error) {
+	st := s.taskStats(t.ID)
+	if st == nil {
+		return
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	if len(st.history) < executionHistorySize {
+		st.history = append(st.history, rec)
+	} else {
+		st.history[st.next] = rec
+	}
+	st.next = (st.next + 1) % executionHistorySize
+
+	st.Runs++
+	if err == nil {
+		st.ConsecutiveFailures = 0
+		st.LastError = ""
+		return
+	}
+	st.Failures++
+	st.ConsecutiveFailures++
+	st.LastError = err.Error()
+}
+
+// failingTasks returns tasks whose last execution failed, ordered by task ID.
+func (s *Scheduler) failingTasks() []FailingTask {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	var failing []FailingTask
+	for id, st := range s.stats {
+		st.mu.Lock()
+		if st.ConsecutiveFailures > 0 {
+			failing = append(failing, FailingTask{
+				TaskID:              id,
+				ConsecutiveFailures: st.ConsecutiveFailures,
+				LastError:           st.LastError,
+			})
+		}
+		st.mu.Unlock()
+	}
+	sort.Slice(failing, func(i, j int) bool {
+		return failing[i].TaskID < failing[j].TaskID
+	})
+	return failing
+}
@@ -13,4 +13,5 @@ type Task struct {
 
 	Running int32
 	Removed int32 // Set to 1 when task is explicitly removed by user
+	Paused  int32 // Set to 1 while the task is paused
 }
@@ -0,0 +1,219 @@
+package golitecron
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrTaskNotFound is returned by control operations for unknown task IDs.
+	ErrTaskNotFound = errors.New("task not found")
+	// ErrInvalidExpression is wrapped by errors for unparsable or unsatisfiable cron expressions.
+	ErrInvalidExpression = errors.New("invalid cron expression")
+	// ErrNotRunning is returned by TriggerTask when the scheduler is stopped.
+	ErrNotRunning = errors.New("scheduler is not running")
+	// ErrTaskRunning is returned by TriggerTask when the task is already executing.
+	ErrTaskRunning = errors.New("task is already running")
+)
+
+// RemoveTaskByID removes the task with the given ID. A running execution is allowed to finish.
+func (s *Scheduler) RemoveTaskByID(taskID string) bool {
+	return s.AsActor(SystemActor).RemoveTaskByID(taskID)
+}
+
+// PauseTask stops scheduling a task until ResumeTask is called. A running execution
+// is allowed to finish. Pausing a paused task is a no-op.
+func (s *Scheduler) PauseTask(taskID string) error {
+	return s.AsActor(SystemActor).PauseTask(taskID)
+}
+
+func (s *Scheduler) pauseTask(taskID string) (before, after *TaskDefinition, err error) {
+	s.taskMu.Lock()
+	defer s.taskMu.Unlock()
+
+	task, ok := s.registry[taskID]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	before = task.definition()
+	if atomic.SwapInt32(&task.Paused, 1) == 0 {
+		s.taskStorage.RemoveTask(task)
+		s.taskChanged(EventTaskPaused, task)
+	}
+
+	return before, task.definition(), nil
+}
+
+// ResumeTask schedules a paused task again from its next fire time after now.
+// Resuming a task that is not paused is a no-op.
+func (s *Scheduler) ResumeTask(taskID string) error {
+	return s.AsActor(SystemActor).ResumeTask(taskID)
+}
+
+func (s *Scheduler) resumeTask(taskID string) (before, after *TaskDefinition, err error) {
+	// Fires lost while paused are reported once taskMu is released, since the SLA
+	// handler may call back into the scheduler.
+	var paused *Task
+	var missed []time.Time
+	var now time.Time
+	defer func() {
+		if paused != nil {
+			s.reportMissed(paused, missed, now)
+		}
+	}()
+
+	s.taskMu.Lock()
+	defer s.taskMu.Unlock()
+
+	task, ok := s.registry[taskID]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	before = task.definition()
+	if atomic.LoadInt32(&task.Paused) == 0 {
+		return before, before, nil
+	}
+
+	nowInTaskZone := time.Now().In(task.CronParser.location)
+	nextRunTime := task.CronParser.Next(nowInTaskZone)
+	if nextRunTime.IsZero() {
+		return before, nil, fmt.Errorf("failed to calculate next run time for task %s: %w: expression may be unsatisfiable", taskID, ErrInvalidExpression)
+	}
+	first := task.NextRunTime
+	if atomic.LoadInt32(&task.Running) == 1 {
+		first = task.CronParser.Next(first) // paused while executing it
+	}
+	paused, missed, now = task, missedFires(task.CronParser, first, nowInTaskZone), nowInTaskZone
+
+	// A new instance keeps a still-running execution of the old one from rescheduling it.
+	resumed := &Task{
+		ID:          task.ID,
+		Job:         task.Job,
+		CronParser:  task.CronParser,
+		NextRunTime: nextRunTime,
+		PreRunTime:  task.PreRunTime,
+	}
+	s.registry[taskID] = resumed
+	s.taskStorage.AddTask(resumed)
+	s.taskChanged(EventTaskResumed, resumed)
+
+	return before, resumed.definition(), nil
+}
+
+// UpdateTask replaces the cron expression of a task. Settings such as location,
+// timeout, retry and the seconds/years fields are kept unless overridden by opts,
+// e.g. WithoutSeconds to switch to a 5-field expression; a CRON_TZ= or TZ= prefix
+// of the old expression is not carried over.
+// A running execution is allowed to finish; the task then follows the new schedule.
+func (s *Scheduler) UpdateTask(taskID string, expr string, opts ...Option) error {
+	return s.AsActor(SystemActor).UpdateTask(taskID, expr, opts...)
+}
+
+func (s *Scheduler) updateTask(taskID string, expr string, opts ...Option) (before, after *TaskDefinition, err error) {
+	s.taskMu.Lock()
+	defer s.taskMu.Unlock()
+
+	task, ok := s.registry[taskID]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	before = task.definition()
+
+	updated, err := newTask(taskID, task.Job, expr, append([]Option{inheritSettings(task.CronParser)}, opts...)...)
+	if err != nil {
+		return before, nil, err
+	}
+	paused := atomic.LoadInt32(&task.Paused)
+	updated.Paused = paused
+
+	s.taskStorage.RemoveTask(task)
+	s.registry[taskID] = updated
+	if paused == 0 {
+		s.taskStorage.AddTask(updated)
+	}
+	s.taskChanged(EventTaskUpdated, updated)
+
+	return before, updated.definition(), nil
+}
+
+// TriggerTask runs a task once now, outside its schedule. The schedule is not
+// affected; a scheduled fire that overlaps the triggered run is skipped.
+func (s *Scheduler) TriggerTask(taskID string) error {
+	return s.AsActor(SystemActor).TriggerTask(taskID)
+}
+
+func (s *Scheduler) triggerTask(taskID string) (*TaskDefinition, error) {
+	// Hold mu so that Stop() cannot start waiting for executions while we add one.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.taskMu.Lock()
+	task, ok := s.registry[taskID]
+	var def *TaskDefinition
+	if ok {
+		def = task.definition()
+	}
+	s.taskMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+
+	if atomic.LoadInt32(&s.running) == 0 {
+		return def, ErrNotRunning
+	}
+	if !s.claimRun(task) {
+		return def, fmt.Errorf("%w: %s", ErrTaskRunning, taskID)
+	}
+
+	s.wg.Add(1)
+	go s.runTriggered(task)
+
+	return def, nil
+}
+
+// runTriggered executes a task out of band. Lateness is not measured since there is no scheduled fire.
+func (s *Scheduler) runTriggered(t *Task) {
+	defer func() {
+		s.wg.Done()
+		if r := recover(); r != nil {
+			s.logger.Printf("Recovered from panic in task %s: %v\n", t.ID, r)
+		}
+	}()
+	defer s.releaseRun(t)
+
+	started := time.Now()
+	execID := s.beginExecution(t, started)
+	defer s.endExecution(execID)
+	s.publishFor(t, Event{Type: EventExecutionStarted, Time: started, ExecutionID: execID, Triggered: true})
+
+	err := s.execute(t, execID)
+	rec := ExecutionRecord{
+		ID:        execID,
+		Started:   started,
+		Finished:  time.Now(),
+		Triggered: true,
+	}
+	s.recordOutcome(t, rec, err)
+	s.publishFinished(t, rec, err)
+}
+
+// inheritSettings copies the non-field settings of p into a new parser.
+func inheritSettings(p *CronParser) Option {
+	return func(n *CronParser) {
+		n.enableSeconds = p.enableSeconds
+		n.enableYears = p.enableYears
+		n.location = p.optionLocation
+		n.timeout = p.timeout
+		n.retry = p.retry
+		n.slaStartDelay = p.slaStartDelay
+		n.slaCompletion = p.slaCompletion
+		n.expectedDuration = p.expectedDuration
+		n.tags = p.tags
+		n.hashSeed = p.hashSeed
+		n.dstGap = p.dstGap
+		n.dstOverlap = p.dstOverlap
+		n.dialect = p.dialect
+	}
+}
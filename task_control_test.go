@@ -0,0 +1,251 @@
+package golitecron
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestControl_PauseResume tests that a paused task does not run until resumed
+func TestControl_PauseResume(t *testing.T) {
+	s := NewScheduler()
+
+	var runs int32
+	job, _ := WrapJob("pausable", func() error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if err := s.PauseTask("pausable"); err != nil {
+		t.Fatalf("PauseTask failed: %v", err)
+	}
+	if err := s.PauseTask("pausable"); err != nil {
+		t.Fatalf("pausing twice should be a no-op: %v", err)
+	}
+
+	info, _ := s.Task("pausable")
+	if info.Status != TaskStatusPaused || !info.NextRunTime.IsZero() {
+		t.Errorf("expected paused task without next run, got %+v", info)
+	}
+
+	s.Start()
+	defer s.Stop()
+	time.Sleep(2200 * time.Millisecond)
+	if n := atomic.LoadInt32(&runs); n != 0 {
+		t.Fatalf("paused task ran %d times", n)
+	}
+
+	if err := s.ResumeTask("pausable"); err != nil {
+		t.Fatalf("ResumeTask failed: %v", err)
+	}
+	time.Sleep(2200 * time.Millisecond)
+	if atomic.LoadInt32(&runs) == 0 {
+		t.Fatal("resumed task did not run")
+	}
+	if info, _ := s.Task("pausable"); info.Status == TaskStatusPaused {
+		t.Errorf("expected task to be unpaused, got %s", info.Status)
+	}
+}
+
+// TestControl_PauseWhileRunning tests that pausing a running task prevents its rescheduling
+func TestControl_PauseWhileRunning(t *testing.T) {
+	s := NewScheduler()
+
+	var runs int32
+	started := make(chan struct{}, 1)
+	job, _ := WrapJob("pause-running", func() error {
+		atomic.AddInt32(&runs, 1)
+		started <- struct{}{}
+		time.Sleep(300 * time.Millisecond)
+		return nil
+	})
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	defer s.Stop()
+	select {
+	case <-started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("task did not start")
+	}
+	if err := s.PauseTask("pause-running"); err != nil {
+		t.Fatalf("PauseTask failed: %v", err)
+	}
+
+	time.Sleep(2 * time.Second)
+	if n := atomic.LoadInt32(&runs); n != 1 {
+		t.Fatalf("expected the paused task to run once, ran %d times", n)
+	}
+	if info, _ := s.Task("pause-running"); info.Status != TaskStatusPaused {
+		t.Errorf("expected paused status, got %s", info.Status)
+	}
+}
+
+// TestControl_UpdateTask tests replacing the expression while keeping settings
+func TestControl_UpdateTask(t *testing.T) {
+	s := NewScheduler()
+	job, _ := WrapJob("updatable", func() error { return nil })
+	if err := s.AddTask("0 0 * * * *", job, WithSeconds(), WithLocation(time.UTC), WithRetry(3)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	if err := s.UpdateTask("updatable", "30 15 10 * * *"); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	info, _ := s.Task("updatable")
+	if info.Expression != "30 15 10 * * *" || info.Retry != 3 || !info.EnableSeconds || info.Location != time.UTC {
+		t.Errorf("unexpected task after update: %+v", info)
+	}
+	next := info.NextRunTime
+	if next.Hour() != 10 || next.Minute() != 15 || next.Second() != 30 {
+		t.Errorf("next run does not follow new expression: %v", next)
+	}
+	if len(s.GetTasks()) != 1 || s.GetTasks()[0].CronParser.expr != "30 15 10 * * *" {
+		t.Error("storage should hold exactly the updated task")
+	}
+
+	if err := s.UpdateTask("updatable", "bad"); !errors.Is(err, ErrInvalidExpression) {
+		t.Errorf("expected ErrInvalidExpression, got %v", err)
+	}
+	if err := s.UpdateTask("missing", "* * * * * *"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+// TestControl_UpdateTaskFields tests switching the seconds and years fields on update
+func TestControl_UpdateTaskFields(t *testing.T) {
+	s := NewScheduler()
+	job, _ := WrapJob("fields", func() error { return nil })
+	if err := s.AddTask("0 0 * * * *", job, WithSeconds(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	if err := s.UpdateTask("fields", "15 10 * * *"); err == nil {
+		t.Error("expected a 5-field expression to be rejected while seconds are kept")
+	}
+	steps := []struct {
+		expr           string
+		opts           []Option
+		seconds, years bool
+	}{
+		{"15 10 * * *", []Option{WithoutSeconds()}, false, false},
+		{"15 10 * * * 2090", []Option{WithYears()}, false, true},
+		{"30 15 10 * * * 2090", []Option{WithSeconds()}, true, true},
+		{"30 15 10 * * *", []Option{WithoutYears()}, true, false},
+	}
+	for _, step := range steps {
+		if err := s.UpdateTask("fields", step.expr, step.opts...); err != nil {
+			t.Fatalf("UpdateTask(%q) failed: %v", step.expr, err)
+		}
+		info, _ := s.Task("fields")
+		if info.EnableSeconds != step.seconds || info.EnableYears != step.years {
+			t.Errorf("%q: seconds %v years %v, want %v %v", step.expr, info.EnableSeconds, info.EnableYears, step.seconds, step.years)
+		}
+		if next := info.NextRunTime; next.Hour() != 10 || next.Minute() != 15 {
+			t.Errorf("%q: next run does not follow the new expression: %v", step.expr, next)
+		}
+	}
+}
+
+// TestControl_TriggerTask tests running a task on demand without changing its schedule
+func TestControl_TriggerTask(t *testing.T) {
+	s := NewScheduler()
+
+	ran := make(chan struct{}, 1)
+	job, _ := WrapJob("triggerable", func() error {
+		ran <- struct{}{}
+		return nil
+	})
+	if err := s.AddTask("0 0 1 1 *", job, WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	before, _ := s.Task("triggerable")
+
+	if err := s.TriggerTask("triggerable"); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("expected ErrNotRunning before Start, got %v", err)
+	}
+
+	s.Start()
+	defer s.Stop()
+	if err := s.TriggerTask("triggerable"); err != nil {
+		t.Fatalf("TriggerTask failed: %v", err)
+	}
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("triggered task did not run")
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	after, _ := s.Task("triggerable")
+	if !after.NextRunTime.Equal(before.NextRunTime) {
+		t.Errorf("trigger changed schedule: %v -> %v", before.NextRunTime, after.NextRunTime)
+	}
+	if after.Stats.Runs != 1 {
+		t.Errorf("expected 1 recorded run, got %d", after.Stats.Runs)
+	}
+	if err := s.TriggerTask("missing"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+// TestControl_TriggerNoOverlap tests that scheduled fires never run alongside a
+// triggered execution, although rescheduling replaces the task instance meanwhile
+func TestControl_TriggerNoOverlap(t *testing.T) {
+	s := NewScheduler()
+
+	var active, maxActive, runs int32
+	job, _ := WrapJob("slow", func() error {
+		n := atomic.AddInt32(&active, 1)
+		for {
+			m := atomic.LoadInt32(&maxActive)
+			if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+				break
+			}
+		}
+		atomic.AddInt32(&runs, 1)
+		time.Sleep(2200 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		return nil
+	})
+	if err := s.AddTask("* * * * * *", job, WithSeconds()); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	if err := s.TriggerTask("slow"); err != nil {
+		t.Fatalf("TriggerTask failed: %v", err)
+	}
+	time.Sleep(5 * time.Second)
+	s.Stop()
+
+	if atomic.LoadInt32(&maxActive) != 1 {
+		t.Errorf("expected executions not to overlap, got %d at once", maxActive)
+	}
+	if atomic.LoadInt32(&runs) < 2 {
+		t.Errorf("expected scheduled runs after the triggered one, got %d runs", runs)
+	}
+}
+
+// TestControl_RemoveTaskByID tests removal by ID
+func TestControl_RemoveTaskByID(t *testing.T) {
+	s := NewScheduler()
+	job, _ := WrapJob("removable", func() error { return nil })
+	if err := s.AddTask("* * * * *", job); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if !s.RemoveTaskByID("removable") {
+		t.Fatal("expected task to be removed")
+	}
+	if s.RemoveTaskByID("removable") {
+		t.Fatal("expected second removal to fail")
+	}
+	if err := s.PauseTask("removable"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+package golitecron
+
+import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// TaskStatus is the lifecycle state of a task.
+type TaskStatus int
+
+const (
+	TaskStatusScheduled TaskStatus = iota
+	TaskStatusRunning
+	TaskStatusPaused
+	TaskStatusRemoved
+)
+
+func (st TaskStatus) String() string {
+	switch st {
+	case TaskStatusScheduled:
+		return "scheduled"
+	case TaskStatusRunning:
+		return "running"
+	case TaskStatusPaused:
+		return "paused"
+	case TaskStatusRemoved:
+		return "removed"
+	default:
+		return fmt.Sprintf("TaskStatus(%d)", int(st))
+	}
+}
+
+// TaskInfo is an immutable snapshot of a task.
+type TaskInfo struct {
+	ID                 string
+	Expression         string // original cron expression, e.g. "@daily" or "*/5 * * * *"
+	ResolvedExpression string // Expression with macros expanded and H tokens resolved, e.g. "37 * * * *"
+	Description        string // expression in plain English, e.g. "At 09:00, on Monday through Friday"
+	Location           *time.Location
+	Timeout            time.Duration
+	Retry              int
+	EnableSeconds      bool
+	EnableYears        bool
+	Tags               []string
+
+	Status      TaskStatus
+	PrevRunTime time.Time // start of the most recent execution; zero if the task has not run
+	NextRunTime time.Time
+	Stats       TaskStats
+}
+
+// Tasks returns snapshots of all registered tasks, including running ones, ordered by ID.
+func (s *Scheduler) Tasks() []TaskInfo {
+	s.taskMu.Lock()
+	defer s.taskMu.Unlock()
+	return s.tasksLocked()
+}
+
+func (s *Scheduler) taskCount() int {
+	s.taskMu.Lock()
+	defer s.taskMu.Unlock()
+	return len(s.registry)
+}
+
+// Task returns a snapshot of the task with the given ID.
+func (s *Scheduler) Task(taskID string) (TaskInfo, bool) {
+	s.taskMu.Lock()
+	defer s.taskMu.Unlock()
+
+	task, ok := s.registry[taskID]
+	if !ok {
+		return TaskInfo{}, false
+	}
+	return s.taskInfo(task), true
+}
+
+// taskInfo builds a snapshot of task. Caller must hold taskMu, which guards
+// the registered instance against being replaced by the run loop.
+func (s *Scheduler) taskInfo(task *Task) TaskInfo {
+	p := task.CronParser
+	stats, _ := s.TaskStats(task.ID)
+
+	info := TaskInfo{
+		ID:                 task.ID,
+		Expression:         p.expr,
+		ResolvedExpression: p.resolved(),
+		Description:        p.describe(),
+		Location:           p.location,
+		Timeout:            p.timeout,
+		Retry:              p.retry,
+		EnableSeconds:      p.enableSeconds,
+		EnableYears:        p.enableYears,
+		Tags:               slices.Clone(p.tags),
+		Status:             task.status(),
+		NextRunTime:        task.NextRunTime,
+		Stats:              stats,
+	}
+	if !stats.LastStarted.IsZero() {
+		info.PrevRunTime = stats.LastStarted.In(p.location)
+	}
+	if atomic.LoadInt32(&task.Paused) == 1 {
+		info.NextRunTime = time.Time{} // not scheduled until resumed
+	}
+	if info.Status != TaskStatusRemoved && s.isExecuting(task.ID) {
+		info.Status = TaskStatusRunning // started on an earlier instance
+	}
+	return info
+}
+
+// UpcomingRuns returns the next n fire times of a task, in the task's location.
+// It returns nil for unknown or paused tasks.
+func (s *Scheduler) UpcomingRuns(taskID string, n int) []time.Time {
+	s.taskMu.Lock()
+	task, ok := s.registry[taskID]
+	s.taskMu.Unlock()
+	if !ok || n <= 0 || atomic.LoadInt32(&task.Paused) == 1 {
+		return nil
+	}
+
+	runs := make([]time.Time, 0, n)
+	for next := task.NextRunTime; !next.IsZero() && len(runs) < n; next = task.CronParser.Next(next) {
+		runs = append(runs, next)
+	}
+	return runs
+}
+
+func (t *Task) status() TaskStatus {
+	switch {
+	case atomic.LoadInt32(&t.Removed) == 1:
+		return TaskStatusRemoved
+	case atomic.LoadInt32(&t.Running) == 1:
+		return TaskStatusRunning
+	case atomic.LoadInt32(&t.Paused) == 1:
+		return TaskStatusPaused
+	default:
+		return TaskStatusScheduled
+	}
+}
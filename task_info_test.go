@@ -0,0 +1,210 @@
+package golitecron
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestTaskInfo_Snapshot tests the fields captured from the task definition
+func TestTaskInfo_Snapshot(t *testing.T) {
+	s := NewScheduler()
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip("Asia/Shanghai timezone not available")
+	}
+
+	job, _ := WrapJob("info", func() error { return nil })
+	if err := s.AddTask("@daily", job, WithLocation(loc), WithTimeout(time.Minute), WithRetry(2)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	job2, _ := WrapJob("another", func() error { return nil })
+	if err := s.AddTask("0 30 9 * * * 2030", job2, WithSeconds(), WithYears(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	info, ok := s.Task("info")
+	if !ok {
+		t.Fatal("expected task to be found")
+	}
+	if info.Expression != "@daily" || info.Location != loc || info.Timeout != time.Minute || info.Retry != 2 {
+		t.Errorf("unexpected definition fields: %+v", info)
+	}
+	if info.EnableSeconds || info.EnableYears {
+		t.Errorf("unexpected field flags: %+v", info)
+	}
+	if info.Status != TaskStatusScheduled || info.NextRunTime.IsZero() || !info.PrevRunTime.IsZero() {
+		t.Errorf("unexpected state fields: %+v", info)
+	}
+
+	tasks := s.Tasks()
+	if len(tasks) != 2 || tasks[0].ID != "another" || tasks[1].ID != "info" {
+		t.Fatalf("expected tasks ordered by ID, got %+v", tasks)
+	}
+	if !tasks[0].EnableSeconds || !tasks[0].EnableYears || tasks[0].NextRunTime.Year() != 2030 {
+		t.Errorf("unexpected snapshot %+v", tasks[0])
+	}
+
+	if _, ok := s.Task("missing"); ok {
+		t.Error("expected unknown task not to be found")
+	}
+}
+
+// TestTaskInfo_RunningAndRemoved tests that running tasks stay visible and removal is reflected
+func TestTaskInfo_RunningAndRemoved(t *testing.T) {
+	s := NewScheduler()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	job, _ := WrapJob("busy", func() error {
+		once.Do(func() { close(started) })
+		<-release
+		return nil
+	})
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	defer s.Stop()
+	select {
+	case <-started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("task did not start")
+	}
+
+	info, ok := s.Task("busy")
+	if !ok || info.Status != TaskStatusRunning {
+		t.Fatalf("expected running task in snapshot, got %+v (found=%v)", info, ok)
+	}
+	if len(s.GetTasks()) != 0 {
+		t.Errorf("running task should not be waiting in storage")
+	}
+	if got := s.Health().TaskCount; got != 1 {
+		t.Errorf("expected running task to be counted, got %d", got)
+	}
+
+	if !s.RemoveTask(&Task{ID: "busy"}) {
+		t.Fatal("expected running task to be removable")
+	}
+	close(release)
+	time.Sleep(1200 * time.Millisecond)
+
+	if _, ok := s.Task("busy"); ok {
+		t.Error("removed task must not be rescheduled")
+	}
+	if len(s.Tasks()) != 0 || len(s.GetTasks()) != 0 {
+		t.Errorf("expected no tasks after removal, got %+v", s.Tasks())
+	}
+
+	// The execution finishing after removal must not leave stats behind.
+	if stats, ok := s.TaskStats("busy"); ok {
+		t.Errorf("expected no stats after removal, got %+v", stats)
+	}
+	idle, _ := WrapJob("busy", func() error { return nil })
+	if err := s.AddTask("0 0 1 1 *", idle, WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	if info, _ := s.Task("busy"); info.Stats.Runs != 0 || len(s.RecentExecutions("busy")) != 0 {
+		t.Errorf("expected a re-added task to start without history, got %+v", info.Stats)
+	}
+}
+
+// TestTaskInfo_DuplicateWhileRunning tests that a running task's ID cannot be reused
+func TestTaskInfo_DuplicateWhileRunning(t *testing.T) {
+	s := NewScheduler()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	job, _ := WrapJob("dup", func() error {
+		once.Do(func() { close(started) })
+		<-release
+		return nil
+	})
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	defer s.Stop()
+	defer close(release)
+	select {
+	case <-started:
+	case <-time.After(3 * time.Second):
+		t.Fatal("task did not start")
+	}
+
+	if err := s.AddTask("* * * * * *", job, WithSeconds()); err == nil {
+		t.Fatal("expected duplicate ID to be rejected while the task runs")
+	}
+}
+
+// TestTaskInfo_UpcomingRuns tests listing future fire times
+func TestTaskInfo_UpcomingRuns(t *testing.T) {
+	s := NewScheduler()
+	job, _ := WrapJob("upcoming", func() error { return nil })
+	if err := s.AddTask("0 9,17 * * *", job, WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	runs := s.UpcomingRuns("upcoming", 3)
+	if len(runs) != 3 {
+		t.Fatalf("expected 3 runs, got %v", runs)
+	}
+	info, _ := s.Task("upcoming")
+	if !runs[0].Equal(info.NextRunTime) {
+		t.Errorf("first run %v should equal NextRunTime %v", runs[0], info.NextRunTime)
+	}
+	for i := 1; i < len(runs); i++ {
+		if d := runs[i].Sub(runs[i-1]); d != 8*time.Hour && d != 16*time.Hour {
+			t.Errorf("unexpected gap %v between %v and %v", d, runs[i-1], runs[i])
+		}
+	}
+	if info.Description != "At 09:00 and 17:00" {
+		t.Errorf("unexpected description %q", info.Description)
+	}
+
+	_ = s.PauseTask("upcoming")
+	if runs := s.UpcomingRuns("upcoming", 3); runs != nil {
+		t.Errorf("expected no runs for paused task, got %v", runs)
+	}
+	if runs := s.UpcomingRuns("missing", 3); runs != nil {
+		t.Errorf("expected no runs for unknown task, got %v", runs)
+	}
+}
+
+// TestTaskInfo_RecentExecutions tests the bounded, newest-first execution history
+func TestTaskInfo_RecentExecutions(t *testing.T) {
+	s := NewScheduler()
+	job, _ := WrapJob("history", func() error { return nil })
+	if err := s.AddTask("0 0 1 1 *", job, WithLocation(time.UTC)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+	task := s.registry["history"]
+	errTest := errors.New("boom")
+
+	for i := 1; i <= executionHistorySize+5; i++ {
+		var err error
+		if i%2 == 0 {
+			err = errTest
+		}
+		s.recordOutcome(task, ExecutionRecord{ID: uint64(i)}, err)
+	}
+
+	records := s.RecentExecutions("history")
+	if len(records) != executionHistorySize {
+		t.Fatalf("expected %d records, got %d", executionHistorySize, len(records))
+	}
+	if records[0].ID != executionHistorySize+5 || records[len(records)-1].ID != 6 {
+		t.Errorf("expected newest first from 25 to 6, got %d..%d", records[0].ID, records[len(records)-1].ID)
+	}
+	if records[1].Error != errTest.Error() || records[0].Error != "" {
+		t.Errorf("unexpected errors in %+v", records[:2])
+	}
+	if s.RecentExecutions("missing") != nil {
+		t.Error("expected no history for unknown task")
+	}
+}
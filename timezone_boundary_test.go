@@ -1,6 +1,8 @@
 package golitecron
 
 import (
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 )
@@ -399,3 +401,276 @@ func TestTimezone_WithSeconds(t *testing.T) {
 		t.Errorf("Expected next within 10 seconds, got %v", diff)
 	}
 }
+
+// ============================================================================
+// DST Policy Tests
+// ============================================================================
+
+var dstPolicies = []struct {
+	gap     DSTGap
+	overlap DSTOverlap
+}{
+	{DSTGapShift, DSTOverlapFirst}, {DSTGapShift, DSTOverlapSecond}, {DSTGapShift, DSTOverlapBoth},
+	{DSTGapSkip, DSTOverlapFirst}, {DSTGapSkip, DSTOverlapSecond}, {DSTGapSkip, DSTOverlapBoth},
+	{DSTGapTransition, DSTOverlapFirst}, {DSTGapTransition, DSTOverlapSecond}, {DSTGapTransition, DSTOverlapBoth},
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("%s timezone not available", name)
+	}
+	return loc
+}
+
+// TestTimezone_DSTPolicy_Gap tests each gap policy for a daily job whose time is skipped.
+func TestTimezone_DSTPolicy_Gap(t *testing.T) {
+	tests := []struct {
+		zone              string
+		expr              string
+		day               time.Time // the day clocks spring forward, in UTC
+		shift, transition string    // expected local fire times on that day
+	}{
+		{"America/New_York", "30 2 * * *", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), "03:30 EDT", "03:00 EDT"},
+		{"Europe/Berlin", "30 2 * * *", time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), "03:30 CEST", "03:00 CEST"},
+		{"Australia/Lord_Howe", "15 2 * * *", time.Date(2024, 10, 6, 0, 0, 0, 0, time.UTC), "02:45 +11", "02:30 +11"},
+		{"America/Santiago", "30 0 * * *", time.Date(2024, 9, 8, 0, 0, 0, 0, time.UTC), "01:30 -03", "01:00 -03"},
+	}
+	for _, tt := range tests {
+		loc := mustLoadLocation(t, tt.zone)
+		y, m, d := tt.day.Date()
+		before := time.Date(y, m, d-1, 12, 0, 0, 0, loc)
+		for gap, want := range map[DSTGap]string{DSTGapShift: tt.shift, DSTGapTransition: tt.transition, DSTGapSkip: ""} {
+			p, err := newCronParser(tt.expr, WithLocation(loc), WithDSTPolicy(gap, DSTOverlapFirst))
+			if err != nil {
+				t.Fatalf("failed to create parser: %v", err)
+			}
+			next := p.Next(before)
+			if want == "" {
+				// Skipped: the next run is on the following day.
+				if next.Format(time.DateOnly) != tt.day.AddDate(0, 0, 1).Format(time.DateOnly) {
+					t.Errorf("%s gap %d: expected the following day, got %v", tt.zone, gap, next)
+				}
+				continue
+			}
+			if got := next.Format("15:04 MST"); next.Day() != d || got != want {
+				t.Errorf("%s gap %d: got %v, want %s", tt.zone, gap, next, want)
+			}
+			if prev := p.Prev(next.Add(time.Minute)); !prev.Equal(next) {
+				t.Errorf("%s gap %d: Prev got %v, want %v", tt.zone, gap, prev, next)
+			}
+		}
+	}
+}
+
+// TestTimezone_DSTPolicy_Overlap tests each overlap policy for a daily job whose time repeats.
+func TestTimezone_DSTPolicy_Overlap(t *testing.T) {
+	tests := []struct {
+		zone          string
+		expr          string
+		day           time.Time // the day clocks fall back, in UTC
+		first, second string    // the two occurrences of the fire time
+	}{
+		{"America/New_York", "30 1 * * *", time.Date(2024, 11, 3, 0, 0, 0, 0, time.UTC), "01:30 EDT", "01:30 EST"},
+		{"Europe/Berlin", "30 2 * * *", time.Date(2024, 10, 27, 0, 0, 0, 0, time.UTC), "02:30 CEST", "02:30 CET"},
+		{"Australia/Lord_Howe", "45 1 * * *", time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC), "01:45 +11", "01:45 +1030"},
+	}
+	for _, tt := range tests {
+		loc := mustLoadLocation(t, tt.zone)
+		y, m, d := tt.day.Date()
+		start, end := time.Date(y, m, d, 0, 0, 0, 0, loc), time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		for overlap, want := range map[DSTOverlap][]string{
+			DSTOverlapFirst:  {tt.first},
+			DSTOverlapSecond: {tt.second},
+			DSTOverlapBoth:   {tt.first, tt.second},
+		} {
+			p, err := newCronParser(tt.expr, WithLocation(loc), WithDSTPolicy(DSTGapShift, overlap))
+			if err != nil {
+				t.Fatalf("failed to create parser: %v", err)
+			}
+			var got []string
+			for at := range p.Between(start, end) {
+				got = append(got, at.Format("15:04 MST"))
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("%s overlap %d: got %v, want %v", tt.zone, overlap, got, want)
+			}
+		}
+	}
+}
+
+// TestTimezone_DSTPolicy_Exhaustive compares Next, Prev, Between and Matches around
+// DST transitions in several zones with fire times computed independently, for every policy.
+func TestTimezone_DSTPolicy_Exhaustive(t *testing.T) {
+	days := []struct {
+		zone string
+		day  time.Time
+	}{
+		{"America/New_York", time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"America/New_York", time.Date(2024, 11, 3, 0, 0, 0, 0, time.UTC)},
+		{"Europe/Berlin", time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"Europe/Berlin", time.Date(2024, 10, 27, 0, 0, 0, 0, time.UTC)},
+		{"Australia/Lord_Howe", time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC)},
+		{"Australia/Lord_Howe", time.Date(2024, 10, 6, 0, 0, 0, 0, time.UTC)},
+		{"America/Santiago", time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC)},
+		{"America/Santiago", time.Date(2024, 9, 8, 0, 0, 0, 0, time.UTC)},
+		{"Pacific/Apia", time.Date(2011, 12, 30, 0, 0, 0, 0, time.UTC)}, // skipped a whole day
+	}
+	// Every 20 minutes, times shifted out of Lord Howe's 30-minute gap interleave
+	// with the wall times after it.
+	exprs := []struct {
+		expr string
+		step time.Duration
+	}{
+		{"*/15 * * * *", 15 * time.Minute},
+		{"*/20 * * * *", 20 * time.Minute},
+	}
+	for _, dd := range days {
+		loc := mustLoadLocation(t, dd.zone)
+		from, to := dd.day.Add(-24*time.Hour), dd.day.Add(48*time.Hour)
+		for _, e := range exprs {
+			for _, pol := range dstPolicies {
+				name := fmt.Sprintf("%q %s %s gap=%d overlap=%d", e.expr, dd.zone, dd.day.Format(time.DateOnly), pol.gap, pol.overlap)
+				p, err := newCronParser(e.expr, WithLocation(loc), WithDSTPolicy(pol.gap, pol.overlap))
+				if err != nil {
+					t.Fatalf("failed to create parser: %v", err)
+				}
+				want := dstOracle(loc, from, to, e.step, pol.gap, pol.overlap)
+
+				var next []time.Time
+				for at := p.Next(from.Add(-time.Nanosecond)); at.Before(to); at = p.Next(at) {
+					next = append(next, at)
+				}
+				if !slices.EqualFunc(next, want, time.Time.Equal) {
+					t.Errorf("%s: Next got %d times, want %d\n%s", name, len(next), len(want), diffTimes(next, want, loc))
+					continue
+				}
+
+				var between []time.Time
+				for at := range p.Between(from, to) {
+					between = append(between, at)
+				}
+				if !slices.EqualFunc(between, want, time.Time.Equal) {
+					t.Errorf("%s: Between got %d times, want %d\n%s", name, len(between), len(want), diffTimes(between, want, loc))
+				}
+
+				var prev []time.Time
+				for at := p.Prev(to); !at.Before(from); at = p.Prev(at) {
+					prev = append(prev, at)
+				}
+				slices.Reverse(prev)
+				if !slices.EqualFunc(prev, want, time.Time.Equal) {
+					t.Errorf("%s: Prev got %d times, want %d\n%s", name, len(prev), len(want), diffTimes(prev, want, loc))
+				}
+
+				for _, at := range want {
+					if !p.Matches(at) {
+						t.Errorf("%s: %v does not match", name, at.In(loc))
+					}
+				}
+
+				// Next and Prev from every minute, not only from fire times.
+				for at := from; at.Before(to); at = at.Add(time.Minute) {
+					i, _ := slices.BinarySearchFunc(want, at, time.Time.Compare)
+					j := i
+					if j < len(want) && want[j].Equal(at) {
+						j++
+					}
+					if j < len(want) {
+						if got := p.Next(at); !got.Equal(want[j]) {
+							t.Errorf("%s: Next(%v) = %v, want %v", name, at.In(loc), got.In(loc), want[j].In(loc))
+						}
+					}
+					if i > 0 {
+						if got := p.Prev(at); !got.Equal(want[i-1]) {
+							t.Errorf("%s: Prev(%v) = %v, want %v", name, at.In(loc), got.In(loc), want[i-1].In(loc))
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// dstOracle returns the fire times in [from, to) of a job running every step of wall
+// clock time under a DST policy. Each wall time is resolved by trying every UTC offset
+// the location uses around the window, without going through time.Date.
+func dstOracle(loc *time.Location, from, to time.Time, step time.Duration, gap DSTGap, overlap DSTOverlap) []time.Time {
+	type transition struct {
+		at            time.Time
+		before, after int
+	}
+	var transitions []transition
+	offsets := make(map[int]bool)
+	_, last := from.Add(-48 * time.Hour).In(loc).Zone()
+	for u := from.Add(-48 * time.Hour); u.Before(to.Add(48 * time.Hour)); u = u.Add(time.Minute) {
+		_, off := u.In(loc).Zone()
+		offsets[off] = true
+		if off != last {
+			transitions = append(transitions, transition{u, last, off})
+		}
+		last = off
+	}
+
+	var times []time.Time
+	wallEnd := to.Add(48 * time.Hour)
+	for w := from.Add(-48 * time.Hour).Truncate(step); w.Before(wallEnd); w = w.Add(step) {
+		// w is read as a wall time: its UTC fields are the local clock reading.
+		var insts []time.Time
+		for off := range offsets {
+			inst := w.Add(-time.Duration(off) * time.Second)
+			local := inst.In(loc)
+			if local.Day() == w.Day() && local.Hour() == w.Hour() && local.Minute() == w.Minute() {
+				insts = append(insts, inst)
+			}
+		}
+		slices.SortFunc(insts, time.Time.Compare)
+		switch {
+		case len(insts) == 0:
+			for _, tr := range transitions {
+				beforeWall := tr.at.Add(time.Duration(tr.before) * time.Second)
+				afterWall := tr.at.Add(time.Duration(tr.after) * time.Second)
+				if w.Before(beforeWall) || !w.Before(afterWall) {
+					continue
+				}
+				switch gap {
+				case DSTGapShift:
+					times = append(times, w.Add(-time.Duration(tr.before)*time.Second))
+				case DSTGapTransition:
+					times = append(times, tr.at)
+				}
+			}
+		case len(insts) == 1:
+			times = append(times, insts[0])
+		case overlap == DSTOverlapFirst:
+			times = append(times, insts[0])
+		case overlap == DSTOverlapSecond:
+			times = append(times, insts[len(insts)-1])
+		default:
+			times = append(times, insts...)
+		}
+	}
+
+	slices.SortFunc(times, time.Time.Compare)
+	times = slices.CompactFunc(times, time.Time.Equal)
+	return slices.DeleteFunc(times, func(at time.Time) bool {
+		return at.Before(from) || !at.Before(to)
+	})
+}
+
+// diffTimes lists the fire times present in only one of got and want.
+func diffTimes(got, want []time.Time, loc *time.Location) string {
+	var b []byte
+	for _, at := range got {
+		if !slices.ContainsFunc(want, at.Equal) {
+			b = fmt.Appendf(b, "  unexpected %v\n", at.In(loc))
+		}
+	}
+	for _, at := range want {
+		if !slices.ContainsFunc(got, at.Equal) {
+			b = fmt.Appendf(b, "  missing %v\n", at.In(loc))
+		}
+	}
+	return string(b)
+}
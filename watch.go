@@ -0,0 +1,110 @@
+package golitecron
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrWatchOverflow is returned by TaskWatch.Err when changes were lost because
+// the watcher fell behind. Subscribe again to start from a fresh snapshot.
+var ErrWatchOverflow = errors.New("task watch overflowed")
+
+// TaskChange is a change to the set of registered tasks.
+type TaskChange struct {
+	Type EventType // EventTaskAdded, EventTaskRemoved, EventTaskUpdated, EventTaskPaused or EventTaskResumed
+	Task TaskInfo  // the task after the change; Status is TaskStatusRemoved for removals
+}
+
+// TaskWatch mirrors the task registry: Snapshot returns the tasks at the time of
+// Subscribe and C delivers every change made after it, in order.
+type TaskWatch struct {
+	s        *Scheduler
+	snapshot []TaskInfo
+	c        chan TaskChange
+	err      error // guarded by the scheduler's taskMu
+}
+
+// Snapshot returns the tasks registered when the watch was created, ordered by ID.
+func (w *TaskWatch) Snapshot() []TaskInfo {
+	return w.snapshot
+}
+
+// C returns the channel changes are delivered on. It is closed by Close, or when
+// the watcher falls behind, in which case Err returns ErrWatchOverflow.
+func (w *TaskWatch) C() <-chan TaskChange {
+	return w.c
+}
+
+// Err returns ErrWatchOverflow if the watch was closed because its buffer was full.
+func (w *TaskWatch) Err() error {
+	w.s.taskMu.Lock()
+	defer w.s.taskMu.Unlock()
+	return w.err
+}
+
+// Close stops delivery and closes the channel.
+func (w *TaskWatch) Close() {
+	w.s.taskMu.Lock()
+	defer w.s.taskMu.Unlock()
+	w.s.unwatchLocked(w)
+}
+
+// Subscribe returns a snapshot of all tasks together with a feed of subsequent
+// changes: adds, removals (including tasks dropped after their last run),
+// updates, pauses and resumes. Taking the snapshot and subscribing is atomic with
+// respect to those operations, so applying the changes to the snapshot keeps a
+// consistent mirror of the registry.
+//
+// Changes are never dropped silently: if more than buffer changes are pending,
+// the watch is closed with ErrWatchOverflow. The caller must Close the watch when done.
+func (s *Scheduler) Subscribe(buffer int) *TaskWatch {
+	w := &TaskWatch{s: s, c: make(chan TaskChange, max(buffer, 0))}
+
+	s.taskMu.Lock()
+	defer s.taskMu.Unlock()
+	w.snapshot = s.tasksLocked()
+	if s.watchers == nil {
+		s.watchers = make(map[*TaskWatch]struct{})
+	}
+	s.watchers[w] = struct{}{}
+	return w
+}
+
+// tasksLocked returns snapshots of all registered tasks ordered by ID. Caller must hold taskMu.
+func (s *Scheduler) tasksLocked() []TaskInfo {
+	infos := make([]TaskInfo, 0, len(s.registry))
+	for _, task := range s.registry {
+		infos = append(infos, s.taskInfo(task))
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// taskChanged publishes a task event and notifies watchers. Caller must hold taskMu.
+func (s *Scheduler) taskChanged(typ EventType, task *Task) {
+	s.publishFor(task, Event{Type: typ})
+	if len(s.watchers) == 0 {
+		return
+	}
+
+	change := TaskChange{Type: typ, Task: s.taskInfo(task)}
+	for w := range s.watchers {
+		select {
+		case w.c <- change:
+		default:
+			w.err = ErrWatchOverflow
+			s.unwatchLocked(w)
+		}
+	}
+}
+
+// unwatchLocked removes w and closes its channel. Caller must hold taskMu.
+func (s *Scheduler) unwatchLocked(w *TaskWatch) {
+	if _, ok := s.watchers[w]; !ok {
+		return
+	}
+	delete(s.watchers, w)
+	close(w.c)
+}
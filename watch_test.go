@@ -0,0 +1,138 @@
+package golitecron
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestSubscribe_SnapshotAndChanges tests the initial snapshot and the change feed
+func TestSubscribe_SnapshotAndChanges(t *testing.T) {
+	s := NewScheduler()
+	for _, id := range []string{"b", "a"} {
+		job, _ := WrapJob(id, func() error { return nil })
+		if err := s.AddTask("0 9 * * *", job); err != nil {
+			t.Fatalf("AddTask failed: %v", err)
+		}
+	}
+
+	w := s.Subscribe(16)
+	defer w.Close()
+	if snap := w.Snapshot(); len(snap) != 2 || snap[0].ID != "a" || snap[1].ID != "b" {
+		t.Fatalf("unexpected snapshot %+v", snap)
+	}
+
+	job, _ := WrapJob("c", func() error { return nil })
+	_ = s.AddTask("0 9 * * *", job)
+	_ = s.PauseTask("a")
+	_ = s.ResumeTask("a")
+	_ = s.UpdateTask("b", "0 10 * * *")
+	s.RemoveTaskByID("c")
+
+	want := []struct {
+		typ    EventType
+		id     string
+		status TaskStatus
+	}{
+		{EventTaskAdded, "c", TaskStatusScheduled},
+		{EventTaskPaused, "a", TaskStatusPaused},
+		{EventTaskResumed, "a", TaskStatusScheduled},
+		{EventTaskUpdated, "b", TaskStatusScheduled},
+		{EventTaskRemoved, "c", TaskStatusRemoved},
+	}
+	for i, wc := range want {
+		ch := <-w.C()
+		if ch.Type != wc.typ || ch.Task.ID != wc.id || ch.Task.Status != wc.status {
+			t.Errorf("change %d: got %v %s %v, want %v %s %v", i, ch.Type, ch.Task.ID, ch.Task.Status, wc.typ, wc.id, wc.status)
+		}
+	}
+	if n := len(w.C()); n != 0 {
+		t.Errorf("unexpected %d extra changes", n)
+	}
+}
+
+// TestSubscribe_ConsistentMirror tests that snapshot plus changes match the registry under concurrent writers
+func TestSubscribe_ConsistentMirror(t *testing.T) {
+	s := NewScheduler()
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				id := fmt.Sprintf("task-%d-%d", g, i)
+				job, _ := WrapJob(id, func() error { return nil })
+				_ = s.AddTask("0 9 * * *", job)
+				if i%3 == 0 {
+					s.RemoveTaskByID(id)
+				}
+			}
+		}()
+	}
+
+	w := s.Subscribe(1000)
+	defer w.Close()
+	wg.Wait()
+
+	mirror := make(map[string]bool)
+	for _, info := range w.Snapshot() {
+		mirror[info.ID] = true
+	}
+	for len(w.C()) > 0 {
+		ch := <-w.C()
+		switch ch.Type {
+		case EventTaskAdded:
+			if mirror[ch.Task.ID] {
+				t.Fatalf("duplicate add of %s", ch.Task.ID)
+			}
+			mirror[ch.Task.ID] = true
+		case EventTaskRemoved:
+			if !mirror[ch.Task.ID] {
+				t.Fatalf("removal of unknown task %s", ch.Task.ID)
+			}
+			delete(mirror, ch.Task.ID)
+		}
+	}
+
+	tasks := s.Tasks()
+	if len(tasks) != len(mirror) {
+		t.Fatalf("mirror has %d tasks, registry has %d", len(mirror), len(tasks))
+	}
+	for _, info := range tasks {
+		if !mirror[info.ID] {
+			t.Errorf("mirror is missing %s", info.ID)
+		}
+	}
+}
+
+// TestSubscribe_Overflow tests that a watcher falling behind is closed rather than losing changes silently
+func TestSubscribe_Overflow(t *testing.T) {
+	s := NewScheduler()
+	w := s.Subscribe(1)
+	defer w.Close()
+
+	for _, id := range []string{"a", "b"} {
+		job, _ := WrapJob(id, func() error { return nil })
+		_ = s.AddTask("0 9 * * *", job)
+	}
+
+	if ch, ok := <-w.C(); !ok || ch.Task.ID != "a" {
+		t.Fatalf("expected the buffered change, got %+v %v", ch, ok)
+	}
+	if _, ok := <-w.C(); ok {
+		t.Fatal("expected the channel to be closed")
+	}
+	if !errors.Is(w.Err(), ErrWatchOverflow) {
+		t.Errorf("expected ErrWatchOverflow, got %v", w.Err())
+	}
+
+	// Close after overflow is a no-op; so is a second Close.
+	w.Close()
+	w2 := s.Subscribe(1)
+	w2.Close()
+	w2.Close()
+	if w2.Err() != nil {
+		t.Errorf("unexpected error %v", w2.Err())
+	}
+}
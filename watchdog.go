@@ -0,0 +1,265 @@
+package golitecron
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultWatchdogInterval = time.Second
+	// DefaultStallThreshold is used by Health() when no watchdog stall threshold is configured.
+	DefaultStallThreshold = 10 * DefaultTickDuration
+)
+
+// WatchdogAlertKind describes what the watchdog detected.
+type WatchdogAlertKind int
+
+const (
+	// AlertStuckTask means an execution has been running longer than its expected duration.
+	AlertStuckTask WatchdogAlertKind = iota
+	// AlertLoopStalled means the dispatch loop has not completed a tick within the stall threshold.
+	AlertLoopStalled
+)
+
+func (k WatchdogAlertKind) String() string {
+	switch k {
+	case AlertStuckTask:
+		return "stuck_task"
+	case AlertLoopStalled:
+		return "loop_stalled"
+	default:
+		return fmt.Sprintf("WatchdogAlertKind(%d)", int(k))
+	}
+}
+
+// WatchdogConfig configures the scheduler watchdog.
+type WatchdogConfig struct {
+	Interval       time.Duration // check interval; defaults to DefaultWatchdogInterval
+	StallThreshold time.Duration // max time without a completed tick; zero disables loop checks
+	OnAlert        func(WatchdogAlert)
+}
+
+// WatchdogAlert is passed to WatchdogConfig.OnAlert. Stuck-task alerts fire once per
+// execution, loop alerts once per stall.
+type WatchdogAlert struct {
+	Kind WatchdogAlertKind
+	Time time.Time
+
+	// Set for AlertStuckTask.
+	Task StuckTask
+
+	// Set for AlertLoopStalled.
+	LastTick time.Time
+}
+
+// StuckTask describes an execution running beyond its expected duration.
+type StuckTask struct {
+	TaskID      string
+	ExecutionID uint64
+	Started     time.Time
+	RunningFor  time.Duration
+	Expected    time.Duration
+}
+
+// FailingTask describes a task whose most recent executions failed.
+type FailingTask struct {
+	TaskID              string
+	ConsecutiveFailures uint64
+	LastError           string
+}
+
+// HealthReport is a point-in-time view of scheduler liveness.
+type HealthReport struct {
+	Running      bool
+	LastTick     time.Time // last completed dispatch loop iteration
+	LoopStalled  bool
+	StorageType  StorageType
+	TaskCount    int
+	InFlight     int // executions currently running
+	StuckTasks   []StuckTask
+	FailingTasks []FailingTask
+}
+
+// execution tracks an in-flight task run.
+type execution struct {
+	id       uint64
+	taskID   string
+	started  time.Time
+	expected time.Duration
+	alerted  bool
+}
+
+type watchdogState struct {
+	mu       sync.Mutex
+	inflight map[uint64]*execution
+	nextID   uint64
+	stalled  bool // loop stall already alerted
+}
+
+// WithWatchdog enables the watchdog. Must be called before Start().
+func (s *Scheduler) WithWatchdog(cfg WatchdogConfig) {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultWatchdogInterval
+	}
+	s.watchdog = &cfg
+}
+
+// Health reports loop liveness and executions running beyond their expected duration.
+func (s *Scheduler) Health() HealthReport {
+	now := time.Now()
+	report := HealthReport{
+		Running:      atomic.LoadInt32(&s.running) == 1,
+		LastTick:     s.lastTick(),
+		StorageType:  s.storageType,
+		TaskCount:    s.taskCount(),
+		InFlight:     s.inFlight(),
+		StuckTasks:   s.stuckTasks(now),
+		FailingTasks: s.failingTasks(),
+	}
+	if report.Running {
+		report.LoopStalled = now.Sub(report.LastTick) > s.stallThreshold()
+	}
+	return report
+}
+
+func (s *Scheduler) stallThreshold() time.Duration {
+	if s.watchdog != nil && s.watchdog.StallThreshold > 0 {
+		return s.watchdog.StallThreshold
+	}
+	return DefaultStallThreshold
+}
+
+func (s *Scheduler) lastTick() time.Time {
+	ns := atomic.LoadInt64(&s.lastTickNano)
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+func (s *Scheduler) markTick(now time.Time) {
+	atomic.StoreInt64(&s.lastTickNano, now.UnixNano())
+}
+
+// beginExecution registers an in-flight execution and returns its ID.
+func (s *Scheduler) beginExecution(t *Task, started time.Time) uint64 {
+	s.wd.mu.Lock()
+	defer s.wd.mu.Unlock()
+
+	s.wd.nextID++
+	s.wd.inflight[s.wd.nextID] = &execution{
+		id:       s.wd.nextID,
+		taskID:   t.ID,
+		started:  started,
+		expected: t.CronParser.expectedDuration,
+	}
+	return s.wd.nextID
+}
+
+func (s *Scheduler) endExecution(id uint64) {
+	s.wd.mu.Lock()
+	delete(s.wd.inflight, id)
+	s.wd.mu.Unlock()
+}
+
+// inFlight returns the number of running executions.
+func (s *Scheduler) inFlight() int {
+	s.wd.mu.Lock()
+	defer s.wd.mu.Unlock()
+	return len(s.wd.inflight)
+}
+
+// stuckTasks returns executions exceeding their expected duration, oldest first.
+func (s *Scheduler) stuckTasks(now time.Time) []StuckTask {
+	s.wd.mu.Lock()
+	defer s.wd.mu.Unlock()
+
+	var stuck []StuckTask
+	for _, e := range s.wd.inflight {
+		if e.expected > 0 && now.Sub(e.started) > e.expected {
+			stuck = append(stuck, e.stuckTask(now))
+		}
+	}
+	sort.Slice(stuck, func(i, j int) bool {
+		return stuck[i].Started.Before(stuck[j].Started)
+	})
+	return stuck
+}
+
+func (e *execution) stuckTask(now time.Time) StuckTask {
+	return StuckTask{
+		TaskID:      e.taskID,
+		ExecutionID: e.id,
+		Started:     e.started,
+		RunningFor:  now.Sub(e.started),
+		Expected:    e.expected,
+	}
+}
+
+// runWatchdog periodically checks for stuck executions and a stalled dispatch loop.
+// It runs independently of run() so that a wedged loop is still observed.
+func (s *Scheduler) runWatchdog(stopChan <-chan struct{}) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.watchdog.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case now := <-ticker.C:
+			s.checkWatchdog(now)
+		}
+	}
+}
+
+func (s *Scheduler) checkWatchdog(now time.Time) {
+	var alerts []WatchdogAlert
+
+	s.wd.mu.Lock()
+	for _, e := range s.wd.inflight {
+		if e.alerted || e.expected <= 0 || now.Sub(e.started) <= e.expected {
+			continue
+		}
+		e.alerted = true
+		alerts = append(alerts, WatchdogAlert{Kind: AlertStuckTask, Time: now, Task: e.stuckTask(now)})
+	}
+
+	if threshold := s.watchdog.StallThreshold; threshold > 0 {
+		lastTick := s.lastTick()
+		stalled := now.Sub(lastTick) > threshold
+		if stalled && !s.wd.stalled {
+			alerts = append(alerts, WatchdogAlert{Kind: AlertLoopStalled, Time: now, LastTick: lastTick})
+		}
+		s.wd.stalled = stalled
+	}
+	s.wd.mu.Unlock()
+
+	for _, alert := range alerts {
+		s.reportWatchdogAlert(alert)
+	}
+}
+
+func (s *Scheduler) reportWatchdogAlert(alert WatchdogAlert) {
+	if s.watchdog.OnAlert == nil {
+		switch alert.Kind {
+		case AlertStuckTask:
+			s.logger.Printf("Watchdog: task %s (execution %d) running for %s, expected %s\n",
+				alert.Task.TaskID, alert.Task.ExecutionID, alert.Task.RunningFor, alert.Task.Expected)
+		case AlertLoopStalled:
+			s.logger.Printf("Watchdog: dispatch loop stalled, last tick at %s\n", alert.LastTick.Format(time.RFC3339))
+		}
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Printf("Recovered from panic in watchdog alert handler: %v\n", r)
+		}
+	}()
+	s.watchdog.OnAlert(alert)
+}
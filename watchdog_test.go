@@ -0,0 +1,127 @@
+package golitecron
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingStorage wraps a TaskStorage and blocks Tick until released, simulating a wedged loop.
+type blockingStorage struct {
+	TaskStorage
+	release chan struct{}
+}
+
+func (b *blockingStorage) Tick(now time.Time) []*Task {
+	<-b.release
+	return b.TaskStorage.Tick(now)
+}
+
+func collectAlerts() (func(WatchdogAlert), func() []WatchdogAlert) {
+	var mu sync.Mutex
+	var got []WatchdogAlert
+	handler := func(a WatchdogAlert) {
+		mu.Lock()
+		got = append(got, a)
+		mu.Unlock()
+	}
+	snapshot := func() []WatchdogAlert {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]WatchdogAlert(nil), got...)
+	}
+	return handler, snapshot
+}
+
+// TestWatchdog_StuckTask tests that a hanging job is alerted once and listed in Health()
+func TestWatchdog_StuckTask(t *testing.T) {
+	s := NewScheduler()
+	handler, alerts := collectAlerts()
+	s.WithWatchdog(WatchdogConfig{Interval: 50 * time.Millisecond, OnAlert: handler})
+
+	release := make(chan struct{})
+	job, _ := WrapJob("hanging", func() error {
+		<-release
+		return nil
+	})
+	if err := s.AddTask("* * * * * *", job, WithSeconds(), WithLocation(time.UTC),
+		WithExpectedDuration(100*time.Millisecond)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	s.Start()
+	time.Sleep(1800 * time.Millisecond)
+
+	report := s.Health()
+	if len(report.StuckTasks) != 1 {
+		close(release)
+		s.Stop()
+		t.Fatalf("expected 1 stuck task, got %+v", report.StuckTasks)
+	}
+	stuck := report.StuckTasks[0]
+	if stuck.TaskID != "hanging" || stuck.RunningFor <= stuck.Expected {
+		t.Errorf("unexpected stuck task %+v", stuck)
+	}
+	if report.LoopStalled {
+		t.Error("loop should not be reported as stalled")
+	}
+
+	close(release)
+	s.Stop()
+
+	got := alerts()
+	if len(got) != 1 {
+		t.Fatalf("expected exactly 1 alert for a single stuck execution, got %d: %+v", len(got), got)
+	}
+	if got[0].Kind != AlertStuckTask || got[0].Task.TaskID != "hanging" {
+		t.Errorf("unexpected alert %+v", got[0])
+	}
+	if len(s.Health().StuckTasks) != 0 {
+		t.Error("expected no stuck tasks after the execution finished")
+	}
+}
+
+// TestWatchdog_LoopStalled tests detection of a dispatch loop that stops completing ticks
+func TestWatchdog_LoopStalled(t *testing.T) {
+	s := NewScheduler()
+	handler, alerts := collectAlerts()
+	s.WithWatchdog(WatchdogConfig{
+		Interval:       50 * time.Millisecond,
+		StallThreshold: 700 * time.Millisecond,
+		OnAlert:        handler,
+	})
+	storage := &blockingStorage{TaskStorage: s.taskStorage, release: make(chan struct{})}
+	s.taskStorage = storage
+
+	s.Start()
+	time.Sleep(1500 * time.Millisecond)
+
+	report := s.Health()
+	if !report.Running || !report.LoopStalled {
+		t.Errorf("expected running but stalled loop, got %+v", report)
+	}
+
+	close(storage.release)
+	time.Sleep(700 * time.Millisecond)
+	if s.Health().LoopStalled {
+		t.Error("loop should recover after Tick is released")
+	}
+	s.Stop()
+
+	got := alerts()
+	if len(got) != 1 || got[0].Kind != AlertLoopStalled {
+		t.Fatalf("expected a single loop stalled alert, got %+v", got)
+	}
+	if got[0].LastTick.IsZero() {
+		t.Error("expected alert to carry the last tick time")
+	}
+}
+
+// TestHealth_NotRunning tests the report of a scheduler that was never started
+func TestHealth_NotRunning(t *testing.T) {
+	s := NewScheduler()
+	report := s.Health()
+	if report.Running || report.LoopStalled || len(report.StuckTasks) != 0 {
+		t.Errorf("unexpected report for idle scheduler: %+v", report)
+	}
+}
@@ -0,0 +1,61 @@
+package golitecron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// splitZonePrefix splits a leading CRON_TZ= or TZ= prefix off an expression, e.g.
+// "CRON_TZ=Asia/Shanghai 0 9 * * *" into "Asia/Shanghai" and "0 9 * * *".
+func splitZonePrefix(expr string) (zone, rest string, ok bool) {
+	expr = strings.TrimSpace(expr)
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		s, found := strings.CutPrefix(expr, prefix)
+		if !found {
+			continue
+		}
+		if i := strings.IndexFunc(s, unicode.IsSpace); i >= 0 {
+			return s[:i], strings.TrimSpace(s[i:]), true
+		}
+		return s, "", true
+	}
+	return "", expr, false
+}
+
+// loadZone resolves the zone of a CRON_TZ= or TZ= prefix: an IANA name such as
+// "Asia/Shanghai", or a fixed offset from UTC written +08, +0800 or +08:00.
+func loadZone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, fmt.Errorf("empty time zone")
+	}
+	if name[0] != '+' && name[0] != '-' {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone %q: %v", name, err)
+		}
+		return loc, nil
+	}
+
+	digits := name[1:]
+	if len(digits) == 5 && digits[2] == ':' {
+		digits = digits[:2] + digits[3:]
+	} else if len(digits) == 2 {
+		digits += "00"
+	}
+	hours, errH := strconv.ParseUint(digits[:min(2, len(digits))], 10, 8)
+	minutes, errM := strconv.ParseUint(digits[min(2, len(digits)):], 10, 8)
+	if len(digits) != 4 || errH != nil || errM != nil || minutes > 59 {
+		return nil, fmt.Errorf("invalid time zone offset %q: want +HH, +HHMM or +HH:MM", name)
+	}
+	if hours*60+minutes > 14*60 {
+		return nil, fmt.Errorf("time zone offset %q out of range -14:00 to +14:00", name)
+	}
+	offset := int(hours*3600 + minutes*60)
+	if name[0] == '-' {
+		offset = -offset
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%c%02d:%02d", name[0], hours, minutes), offset), nil
+}
@@ -0,0 +1,113 @@
+package golitecron
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestZonePrefix tests CRON_TZ= and TZ= prefixes and their precedence over WithLocation
+func TestZonePrefix(t *testing.T) {
+	shanghai := mustLoadLocation(t, "Asia/Shanghai")
+	newYork := mustLoadLocation(t, "America/New_York")
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		opts []Option
+		zone string
+		next time.Time
+	}{
+		{"CRON_TZ=Asia/Shanghai 0 9 * * *", nil, "Asia/Shanghai", time.Date(2024, 1, 1, 9, 0, 0, 0, shanghai)},
+		{"TZ=Asia/Shanghai 0 9 * * *", nil, "Asia/Shanghai", time.Date(2024, 1, 1, 9, 0, 0, 0, shanghai)},
+		{"CRON_TZ=Asia/Shanghai 0 9 * * *", []Option{WithLocation(newYork)}, "Asia/Shanghai", time.Date(2024, 1, 1, 9, 0, 0, 0, shanghai)},
+		{"0 9 * * *", []Option{WithLocation(newYork)}, "America/New_York", time.Date(2024, 1, 1, 9, 0, 0, 0, newYork)},
+		{"  CRON_TZ=UTC\t@daily", nil, "UTC", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"TZ=+08:00 0 9 * * *", nil, "UTC+08:00", time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{"TZ=+0800 0 9 * * *", nil, "UTC+08:00", time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{"TZ=+08 0 9 * * *", nil, "UTC+08:00", time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{"CRON_TZ=-05:30 0 9 * * *", nil, "UTC-05:30", time.Date(2024, 1, 1, 14, 30, 0, 0, time.UTC)},
+		{"TZ=+14:00 0 9 * * *", []Option{WithSeconds()}, "", time.Time{}}, // six fields required
+		{"TZ=+14:00 0 0 9 * * *", []Option{WithSeconds()}, "UTC+14:00", time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr, tt.opts...)
+		if tt.zone == "" {
+			if err == nil {
+				t.Errorf("Parse(%q): expected an error", tt.expr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.expr, err)
+			continue
+		}
+		if got := s.Location().String(); got != tt.zone {
+			t.Errorf("Parse(%q): location %s, want %s", tt.expr, got, tt.zone)
+		}
+		if got := s.Next(from); !got.Equal(tt.next) {
+			t.Errorf("Parse(%q).Next = %v, want %v", tt.expr, got, tt.next)
+		}
+	}
+}
+
+// TestZonePrefix_Invalid tests that malformed or unknown zones are rejected
+func TestZonePrefix_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"CRON_TZ=Nowhere/City 0 9 * * *",
+		"CRON_TZ= 0 9 * * *",
+		"CRON_TZ=UTC",
+		"TZ=+8 0 9 * * *",
+		"TZ=+8:00 0 9 * * *",
+		"TZ=+08:0 0 9 * * *",
+		"TZ=+08:60 0 9 * * *",
+		"TZ=+14:30 0 9 * * *",
+		"TZ=-15 0 9 * * *",
+		"TZ=++0800 0 9 * * *",
+		"0 9 * * * CRON_TZ=UTC",
+	} {
+		if _, err := Parse(expr); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("Parse(%q): expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+
+	_, err := Parse("CRON_TZ=Nowhere/City 0 9 * * *")
+	if err == nil || !strings.Contains(err.Error(), "Nowhere/City") {
+		t.Errorf("expected the error to name the zone, got %v", err)
+	}
+}
+
+// TestZonePrefix_TaskInfo tests that tasks report the zone in effect and that updates
+// do not carry a prefix over
+func TestZonePrefix_TaskInfo(t *testing.T) {
+	shanghai := mustLoadLocation(t, "Asia/Shanghai")
+	newYork := mustLoadLocation(t, "America/New_York")
+	s := NewScheduler()
+	job, _ := WrapJob("report", func() error { return nil })
+	if err := s.AddTask("CRON_TZ=Asia/Shanghai 0 9 * * *", job, WithLocation(newYork)); err != nil {
+		t.Fatalf("AddTask failed: %v", err)
+	}
+
+	info, _ := s.Task("report")
+	if info.Location.String() != "Asia/Shanghai" || info.Expression != "CRON_TZ=Asia/Shanghai 0 9 * * *" || info.ResolvedExpression != "0 9 * * *" {
+		t.Errorf("unexpected snapshot: location %v, expression %q, resolved %q", info.Location, info.Expression, info.ResolvedExpression)
+	}
+	if got := info.NextRunTime.In(shanghai); got.Hour() != 9 || got.Minute() != 0 {
+		t.Errorf("expected next run at 09:00 Shanghai time, got %v", got)
+	}
+
+	if err := s.UpdateTask("report", "0 10 * * *"); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	if info, _ = s.Task("report"); info.Location != newYork {
+		t.Errorf("expected the update to fall back to WithLocation, got %v", info.Location)
+	}
+
+	if err := s.UpdateTask("report", "TZ=+08:00 0 10 * * *"); err != nil {
+		t.Fatalf("UpdateTask failed: %v", err)
+	}
+	if info, _ = s.Task("report"); info.Location.String() != "UTC+08:00" {
+		t.Errorf("expected a fixed offset zone, got %v", info.Location)
+	}
+}